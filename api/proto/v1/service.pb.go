@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -22,122 +23,1097 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// ImageSource limits the search for an image to the given source, see: Google's "source" parameter.
+type ImageSource int32
+
+const (
+	ImageSource_DEFAULT ImageSource = 0
+	ImageSource_OUTDOOR ImageSource = 1
+)
+
+var ImageSource_name = map[int32]string{
+	0: "DEFAULT",
+	1: "OUTDOOR",
+}
+
+var ImageSource_value = map[string]int32{
+	"DEFAULT": 0,
+	"OUTDOOR": 1,
+}
+
+func (x ImageSource) String() string {
+	return proto.EnumName(ImageSource_name, int32(x))
+}
+
+func (ImageSource) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{0}
+}
+
 type GetStreetViewRequest struct {
+	CorrelationId string  `protobuf:"bytes,1,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
+	Latitude      float32 `protobuf:"fixed32,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32 `protobuf:"fixed32,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// The camera parameters are optional - when not provided Google's (or our configured) defaults are used.
+	Heading *wrappers.FloatValue  `protobuf:"bytes,4,opt,name=heading,proto3" json:"heading,omitempty"`
+	Pitch   *wrappers.FloatValue  `protobuf:"bytes,5,opt,name=pitch,proto3" json:"pitch,omitempty"`
+	Fov     *wrappers.UInt32Value `protobuf:"bytes,6,opt,name=fov,proto3" json:"fov,omitempty"`
+	Radius  *wrappers.UInt32Value `protobuf:"bytes,7,opt,name=radius,proto3" json:"radius,omitempty"`
+	Source  ImageSource           `protobuf:"varint,8,opt,name=source,proto3,enum=v1.ImageSource" json:"source,omitempty"`
+	// The size is optional too: both width and height (up to 640) must be provided. Scale 2 depends on the api plan.
+	Width                *wrappers.UInt32Value `protobuf:"bytes,9,opt,name=width,proto3" json:"width,omitempty"`
+	Height               *wrappers.UInt32Value `protobuf:"bytes,10,opt,name=height,proto3" json:"height,omitempty"`
+	Scale                *wrappers.UInt32Value `protobuf:"bytes,11,opt,name=scale,proto3" json:"scale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetStreetViewRequest) Reset()         { *m = GetStreetViewRequest{} }
+func (m *GetStreetViewRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewRequest) ProtoMessage()    {}
+func (*GetStreetViewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{0}
+}
+
+func (m *GetStreetViewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewRequest.Unmarshal(m, b)
+}
+func (m *GetStreetViewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewRequest.Merge(m, src)
+}
+func (m *GetStreetViewRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewRequest.Size(m)
+}
+func (m *GetStreetViewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewRequest proto.InternalMessageInfo
+
+func (m *GetStreetViewRequest) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *GetStreetViewRequest) GetLatitude() float32 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewRequest) GetLongitude() float32 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewRequest) GetHeading() *wrappers.FloatValue {
+	if m != nil {
+		return m.Heading
+	}
+	return nil
+}
+
+func (m *GetStreetViewRequest) GetPitch() *wrappers.FloatValue {
+	if m != nil {
+		return m.Pitch
+	}
+	return nil
+}
+
+func (m *GetStreetViewRequest) GetFov() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Fov
+	}
+	return nil
+}
+
+func (m *GetStreetViewRequest) GetRadius() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Radius
+	}
+	return nil
+}
+
+func (m *GetStreetViewRequest) GetSource() ImageSource {
+	if m != nil {
+		return m.Source
+	}
+	return ImageSource_DEFAULT
+}
+
+func (m *GetStreetViewRequest) GetWidth() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Width
+	}
+	return nil
+}
+
+func (m *GetStreetViewRequest) GetHeight() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Height
+	}
+	return nil
+}
+
+func (m *GetStreetViewRequest) GetScale() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Scale
+	}
+	return nil
+}
+
+type GetStreetViewResponse struct {
+	Image []byte `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// The imagery provider the image was retrieved from, for example "google" or "mapillary".
+	Provider             string   `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStreetViewResponse) Reset()         { *m = GetStreetViewResponse{} }
+func (m *GetStreetViewResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewResponse) ProtoMessage()    {}
+func (*GetStreetViewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{1}
+}
+
+func (m *GetStreetViewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewResponse.Unmarshal(m, b)
+}
+func (m *GetStreetViewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewResponse.Merge(m, src)
+}
+func (m *GetStreetViewResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewResponse.Size(m)
+}
+func (m *GetStreetViewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewResponse proto.InternalMessageInfo
+
+func (m *GetStreetViewResponse) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+func (m *GetStreetViewResponse) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+type GetStreetViewImagesRequest struct {
+	CorrelationId        string                `protobuf:"bytes,1,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
+	Locations            []*StreetViewLocation `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetStreetViewImagesRequest) Reset()         { *m = GetStreetViewImagesRequest{} }
+func (m *GetStreetViewImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewImagesRequest) ProtoMessage()    {}
+func (*GetStreetViewImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{2}
+}
+
+func (m *GetStreetViewImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewImagesRequest.Unmarshal(m, b)
+}
+func (m *GetStreetViewImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewImagesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewImagesRequest.Merge(m, src)
+}
+func (m *GetStreetViewImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewImagesRequest.Size(m)
+}
+func (m *GetStreetViewImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewImagesRequest proto.InternalMessageInfo
+
+func (m *GetStreetViewImagesRequest) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *GetStreetViewImagesRequest) GetLocations() []*StreetViewLocation {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+// StreetViewLocation is a single location in a batch request, with the same optional camera parameters and size.
+type StreetViewLocation struct {
+	Latitude             float32               `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float32               `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Heading              *wrappers.FloatValue  `protobuf:"bytes,3,opt,name=heading,proto3" json:"heading,omitempty"`
+	Pitch                *wrappers.FloatValue  `protobuf:"bytes,4,opt,name=pitch,proto3" json:"pitch,omitempty"`
+	Fov                  *wrappers.UInt32Value `protobuf:"bytes,5,opt,name=fov,proto3" json:"fov,omitempty"`
+	Radius               *wrappers.UInt32Value `protobuf:"bytes,6,opt,name=radius,proto3" json:"radius,omitempty"`
+	Source               ImageSource           `protobuf:"varint,7,opt,name=source,proto3,enum=v1.ImageSource" json:"source,omitempty"`
+	Width                *wrappers.UInt32Value `protobuf:"bytes,8,opt,name=width,proto3" json:"width,omitempty"`
+	Height               *wrappers.UInt32Value `protobuf:"bytes,9,opt,name=height,proto3" json:"height,omitempty"`
+	Scale                *wrappers.UInt32Value `protobuf:"bytes,10,opt,name=scale,proto3" json:"scale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *StreetViewLocation) Reset()         { *m = StreetViewLocation{} }
+func (m *StreetViewLocation) String() string { return proto.CompactTextString(m) }
+func (*StreetViewLocation) ProtoMessage()    {}
+func (*StreetViewLocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{3}
+}
+
+func (m *StreetViewLocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreetViewLocation.Unmarshal(m, b)
+}
+func (m *StreetViewLocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreetViewLocation.Marshal(b, m, deterministic)
+}
+func (m *StreetViewLocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreetViewLocation.Merge(m, src)
+}
+func (m *StreetViewLocation) XXX_Size() int {
+	return xxx_messageInfo_StreetViewLocation.Size(m)
+}
+func (m *StreetViewLocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreetViewLocation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreetViewLocation proto.InternalMessageInfo
+
+func (m *StreetViewLocation) GetLatitude() float32 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *StreetViewLocation) GetLongitude() float32 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *StreetViewLocation) GetHeading() *wrappers.FloatValue {
+	if m != nil {
+		return m.Heading
+	}
+	return nil
+}
+
+func (m *StreetViewLocation) GetPitch() *wrappers.FloatValue {
+	if m != nil {
+		return m.Pitch
+	}
+	return nil
+}
+
+func (m *StreetViewLocation) GetFov() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Fov
+	}
+	return nil
+}
+
+func (m *StreetViewLocation) GetRadius() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Radius
+	}
+	return nil
+}
+
+func (m *StreetViewLocation) GetSource() ImageSource {
+	if m != nil {
+		return m.Source
+	}
+	return ImageSource_DEFAULT
+}
+
+func (m *StreetViewLocation) GetWidth() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Width
+	}
+	return nil
+}
+
+func (m *StreetViewLocation) GetHeight() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Height
+	}
+	return nil
+}
+
+func (m *StreetViewLocation) GetScale() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Scale
+	}
+	return nil
+}
+
+// GetStreetViewImagesResponse contains one result per requested location, in the same order as the request.
+type GetStreetViewImagesResponse struct {
+	Results              []*StreetViewImageResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetStreetViewImagesResponse) Reset()         { *m = GetStreetViewImagesResponse{} }
+func (m *GetStreetViewImagesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewImagesResponse) ProtoMessage()    {}
+func (*GetStreetViewImagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{4}
+}
+
+func (m *GetStreetViewImagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewImagesResponse.Unmarshal(m, b)
+}
+func (m *GetStreetViewImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewImagesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewImagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewImagesResponse.Merge(m, src)
+}
+func (m *GetStreetViewImagesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewImagesResponse.Size(m)
+}
+func (m *GetStreetViewImagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewImagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewImagesResponse proto.InternalMessageInfo
+
+func (m *GetStreetViewImagesResponse) GetResults() []*StreetViewImageResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// StreetViewImageResult contains either the image or, when code is not OK (0), the grpc code and error.
+type StreetViewImageResult struct {
+	Image                []byte   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Code                 uint32   `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Provider             string   `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreetViewImageResult) Reset()         { *m = StreetViewImageResult{} }
+func (m *StreetViewImageResult) String() string { return proto.CompactTextString(m) }
+func (*StreetViewImageResult) ProtoMessage()    {}
+func (*StreetViewImageResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{5}
+}
+
+func (m *StreetViewImageResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreetViewImageResult.Unmarshal(m, b)
+}
+func (m *StreetViewImageResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreetViewImageResult.Marshal(b, m, deterministic)
+}
+func (m *StreetViewImageResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreetViewImageResult.Merge(m, src)
+}
+func (m *StreetViewImageResult) XXX_Size() int {
+	return xxx_messageInfo_StreetViewImageResult.Size(m)
+}
+func (m *StreetViewImageResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreetViewImageResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreetViewImageResult proto.InternalMessageInfo
+
+func (m *StreetViewImageResult) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+func (m *StreetViewImageResult) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *StreetViewImageResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *StreetViewImageResult) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+// GetStreetViewMetadataRequest checks the coverage at a location without requesting (and paying for) an image.
+type GetStreetViewMetadataRequest struct {
+	CorrelationId        string                `protobuf:"bytes,1,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
+	Latitude             float32               `protobuf:"fixed32,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float32               `protobuf:"fixed32,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Radius               *wrappers.UInt32Value `protobuf:"bytes,4,opt,name=radius,proto3" json:"radius,omitempty"`
+	Source               ImageSource           `protobuf:"varint,5,opt,name=source,proto3,enum=v1.ImageSource" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetStreetViewMetadataRequest) Reset()         { *m = GetStreetViewMetadataRequest{} }
+func (m *GetStreetViewMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewMetadataRequest) ProtoMessage()    {}
+func (*GetStreetViewMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{6}
+}
+
+func (m *GetStreetViewMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewMetadataRequest.Unmarshal(m, b)
+}
+func (m *GetStreetViewMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewMetadataRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewMetadataRequest.Merge(m, src)
+}
+func (m *GetStreetViewMetadataRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewMetadataRequest.Size(m)
+}
+func (m *GetStreetViewMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewMetadataRequest proto.InternalMessageInfo
+
+func (m *GetStreetViewMetadataRequest) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *GetStreetViewMetadataRequest) GetLatitude() float32 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewMetadataRequest) GetLongitude() float32 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewMetadataRequest) GetRadius() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Radius
+	}
+	return nil
+}
+
+func (m *GetStreetViewMetadataRequest) GetSource() ImageSource {
+	if m != nil {
+		return m.Source
+	}
+	return ImageSource_DEFAULT
+}
+
+// GetStreetViewMetadataResponse describes the panorama at a location, only when status is "OK" is an image available.
+type GetStreetViewMetadataResponse struct {
+	Status    string  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PanoId    string  `protobuf:"bytes,2,opt,name=panoId,proto3" json:"panoId,omitempty"`
+	Date      string  `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Copyright string  `protobuf:"bytes,4,opt,name=copyright,proto3" json:"copyright,omitempty"`
+	Latitude  float64 `protobuf:"fixed64,5,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,6,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// The imagery provider describing the panorama, the first with coverage when several are configured.
+	Provider             string   `protobuf:"bytes,7,opt,name=provider,proto3" json:"provider,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStreetViewMetadataResponse) Reset()         { *m = GetStreetViewMetadataResponse{} }
+func (m *GetStreetViewMetadataResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewMetadataResponse) ProtoMessage()    {}
+func (*GetStreetViewMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{7}
+}
+
+func (m *GetStreetViewMetadataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewMetadataResponse.Unmarshal(m, b)
+}
+func (m *GetStreetViewMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewMetadataResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewMetadataResponse.Merge(m, src)
+}
+func (m *GetStreetViewMetadataResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewMetadataResponse.Size(m)
+}
+func (m *GetStreetViewMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewMetadataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewMetadataResponse proto.InternalMessageInfo
+
+func (m *GetStreetViewMetadataResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetStreetViewMetadataResponse) GetPanoId() string {
+	if m != nil {
+		return m.PanoId
+	}
+	return ""
+}
+
+func (m *GetStreetViewMetadataResponse) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *GetStreetViewMetadataResponse) GetCopyright() string {
+	if m != nil {
+		return m.Copyright
+	}
+	return ""
+}
+
+func (m *GetStreetViewMetadataResponse) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewMetadataResponse) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewMetadataResponse) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+// GetStreetViewPanoRequest requests an image of exactly the given panorama, for example a panoId from the metadata.
+type GetStreetViewPanoRequest struct {
+	CorrelationId        string                `protobuf:"bytes,1,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
+	PanoId               string                `protobuf:"bytes,2,opt,name=panoId,proto3" json:"panoId,omitempty"`
+	Heading              *wrappers.FloatValue  `protobuf:"bytes,3,opt,name=heading,proto3" json:"heading,omitempty"`
+	Pitch                *wrappers.FloatValue  `protobuf:"bytes,4,opt,name=pitch,proto3" json:"pitch,omitempty"`
+	Fov                  *wrappers.UInt32Value `protobuf:"bytes,5,opt,name=fov,proto3" json:"fov,omitempty"`
+	Width                *wrappers.UInt32Value `protobuf:"bytes,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height               *wrappers.UInt32Value `protobuf:"bytes,7,opt,name=height,proto3" json:"height,omitempty"`
+	Scale                *wrappers.UInt32Value `protobuf:"bytes,8,opt,name=scale,proto3" json:"scale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetStreetViewPanoRequest) Reset()         { *m = GetStreetViewPanoRequest{} }
+func (m *GetStreetViewPanoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewPanoRequest) ProtoMessage()    {}
+func (*GetStreetViewPanoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{8}
+}
+
+func (m *GetStreetViewPanoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewPanoRequest.Unmarshal(m, b)
+}
+func (m *GetStreetViewPanoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewPanoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewPanoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewPanoRequest.Merge(m, src)
+}
+func (m *GetStreetViewPanoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewPanoRequest.Size(m)
+}
+func (m *GetStreetViewPanoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewPanoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewPanoRequest proto.InternalMessageInfo
+
+func (m *GetStreetViewPanoRequest) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *GetStreetViewPanoRequest) GetPanoId() string {
+	if m != nil {
+		return m.PanoId
+	}
+	return ""
+}
+
+func (m *GetStreetViewPanoRequest) GetHeading() *wrappers.FloatValue {
+	if m != nil {
+		return m.Heading
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoRequest) GetPitch() *wrappers.FloatValue {
+	if m != nil {
+		return m.Pitch
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoRequest) GetFov() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Fov
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoRequest) GetWidth() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Width
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoRequest) GetHeight() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Height
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoRequest) GetScale() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Scale
+	}
+	return nil
+}
+
+// GetStreetViewPanoramaRequest requests a 360 degree panorama, stitched side by side from evenly spaced headings.
+type GetStreetViewPanoramaRequest struct {
+	CorrelationId string  `protobuf:"bytes,1,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
+	Latitude      float32 `protobuf:"fixed32,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32 `protobuf:"fixed32,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// The number of slices, from 3 to 12, defaults to 4 when not provided.
+	Slices uint32 `protobuf:"varint,4,opt,name=slices,proto3" json:"slices,omitempty"`
+	// The size of each slice, the panorama is slices * width wide. Both or neither must be provided.
+	Width                *wrappers.UInt32Value `protobuf:"bytes,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height               *wrappers.UInt32Value `protobuf:"bytes,6,opt,name=height,proto3" json:"height,omitempty"`
+	Pitch                *wrappers.FloatValue  `protobuf:"bytes,7,opt,name=pitch,proto3" json:"pitch,omitempty"`
+	Radius               *wrappers.UInt32Value `protobuf:"bytes,8,opt,name=radius,proto3" json:"radius,omitempty"`
+	Source               ImageSource           `protobuf:"varint,9,opt,name=source,proto3,enum=v1.ImageSource" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetStreetViewPanoramaRequest) Reset()         { *m = GetStreetViewPanoramaRequest{} }
+func (m *GetStreetViewPanoramaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewPanoramaRequest) ProtoMessage()    {}
+func (*GetStreetViewPanoramaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{9}
+}
+
+func (m *GetStreetViewPanoramaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewPanoramaRequest.Unmarshal(m, b)
+}
+func (m *GetStreetViewPanoramaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewPanoramaRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewPanoramaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewPanoramaRequest.Merge(m, src)
+}
+func (m *GetStreetViewPanoramaRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewPanoramaRequest.Size(m)
+}
+func (m *GetStreetViewPanoramaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewPanoramaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewPanoramaRequest proto.InternalMessageInfo
+
+func (m *GetStreetViewPanoramaRequest) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *GetStreetViewPanoramaRequest) GetLatitude() float32 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewPanoramaRequest) GetLongitude() float32 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *GetStreetViewPanoramaRequest) GetSlices() uint32 {
+	if m != nil {
+		return m.Slices
+	}
+	return 0
+}
+
+func (m *GetStreetViewPanoramaRequest) GetWidth() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Width
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoramaRequest) GetHeight() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Height
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoramaRequest) GetPitch() *wrappers.FloatValue {
+	if m != nil {
+		return m.Pitch
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoramaRequest) GetRadius() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Radius
+	}
+	return nil
+}
+
+func (m *GetStreetViewPanoramaRequest) GetSource() ImageSource {
+	if m != nil {
+		return m.Source
+	}
+	return ImageSource_DEFAULT
+}
+
+// GetStreetViewUsageRequest requests the billable (image) requests made to the StreetView API this month.
+type GetStreetViewUsageRequest struct {
 	CorrelationId        string   `protobuf:"bytes,1,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
-	Latitude             float32  `protobuf:"fixed32,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude            float32  `protobuf:"fixed32,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetStreetViewRequest) Reset()         { *m = GetStreetViewRequest{} }
-func (m *GetStreetViewRequest) String() string { return proto.CompactTextString(m) }
-func (*GetStreetViewRequest) ProtoMessage()    {}
-func (*GetStreetViewRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_89fdd0f41cb65e4f, []int{0}
+func (m *GetStreetViewUsageRequest) Reset()         { *m = GetStreetViewUsageRequest{} }
+func (m *GetStreetViewUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewUsageRequest) ProtoMessage()    {}
+func (*GetStreetViewUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{10}
 }
 
-func (m *GetStreetViewRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetStreetViewRequest.Unmarshal(m, b)
+func (m *GetStreetViewUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewUsageRequest.Unmarshal(m, b)
 }
-func (m *GetStreetViewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetStreetViewRequest.Marshal(b, m, deterministic)
+func (m *GetStreetViewUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewUsageRequest.Marshal(b, m, deterministic)
 }
-func (m *GetStreetViewRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetStreetViewRequest.Merge(m, src)
+func (m *GetStreetViewUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewUsageRequest.Merge(m, src)
 }
-func (m *GetStreetViewRequest) XXX_Size() int {
-	return xxx_messageInfo_GetStreetViewRequest.Size(m)
+func (m *GetStreetViewUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewUsageRequest.Size(m)
 }
-func (m *GetStreetViewRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetStreetViewRequest.DiscardUnknown(m)
+func (m *GetStreetViewUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewUsageRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetStreetViewRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetStreetViewUsageRequest proto.InternalMessageInfo
 
-func (m *GetStreetViewRequest) GetCorrelationId() string {
+func (m *GetStreetViewUsageRequest) GetCorrelationId() string {
 	if m != nil {
 		return m.CorrelationId
 	}
 	return ""
 }
 
-func (m *GetStreetViewRequest) GetLatitude() float32 {
+// GetStreetViewUsageResponse contains the billable requests made in the (UTC) month, formatted as: 2006-01.
+type GetStreetViewUsageResponse struct {
+	Month            string `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	BillableRequests uint64 `protobuf:"varint,2,opt,name=billableRequests,proto3" json:"billableRequests,omitempty"`
+	// The billable requests permitted in the month, 0 meaning unlimited.
+	Budget uint64 `protobuf:"varint,3,opt,name=budget,proto3" json:"budget,omitempty"`
+	// The calls made with each api key, counted by the instance that responded since it started.
+	ApiKeys              []*StreetViewApiKeyUsage `protobuf:"bytes,4,rep,name=apiKeys,proto3" json:"apiKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetStreetViewUsageResponse) Reset()         { *m = GetStreetViewUsageResponse{} }
+func (m *GetStreetViewUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStreetViewUsageResponse) ProtoMessage()    {}
+func (*GetStreetViewUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{11}
+}
+
+func (m *GetStreetViewUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStreetViewUsageResponse.Unmarshal(m, b)
+}
+func (m *GetStreetViewUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStreetViewUsageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStreetViewUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStreetViewUsageResponse.Merge(m, src)
+}
+func (m *GetStreetViewUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStreetViewUsageResponse.Size(m)
+}
+func (m *GetStreetViewUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStreetViewUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStreetViewUsageResponse proto.InternalMessageInfo
+
+func (m *GetStreetViewUsageResponse) GetMonth() string {
 	if m != nil {
-		return m.Latitude
+		return m.Month
+	}
+	return ""
+}
+
+func (m *GetStreetViewUsageResponse) GetBillableRequests() uint64 {
+	if m != nil {
+		return m.BillableRequests
 	}
 	return 0
 }
 
-func (m *GetStreetViewRequest) GetLongitude() float32 {
+func (m *GetStreetViewUsageResponse) GetBudget() uint64 {
 	if m != nil {
-		return m.Longitude
+		return m.Budget
 	}
 	return 0
 }
 
-type GetStreetViewResponse struct {
-	Image                []byte   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+func (m *GetStreetViewUsageResponse) GetApiKeys() []*StreetViewApiKeyUsage {
+	if m != nil {
+		return m.ApiKeys
+	}
+	return nil
+}
+
+// StreetViewApiKeyUsage contains the calls made with an api key, identified by it's last few characters only.
+type StreetViewApiKeyUsage struct {
+	Id               string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Weight           uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Requests         uint64 `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	BillableRequests uint64 `protobuf:"varint,4,opt,name=billableRequests,proto3" json:"billableRequests,omitempty"`
+	// The times google refused the key as over it's quota or denied, quarantining it.
+	Refusals uint64 `protobuf:"varint,5,opt,name=refusals,proto3" json:"refusals,omitempty"`
+	// When a quarantined key is used again (RFC 3339), empty when the key is not quarantined.
+	QuarantinedUntil     string   `protobuf:"bytes,6,opt,name=quarantinedUntil,proto3" json:"quarantinedUntil,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetStreetViewResponse) Reset()         { *m = GetStreetViewResponse{} }
-func (m *GetStreetViewResponse) String() string { return proto.CompactTextString(m) }
-func (*GetStreetViewResponse) ProtoMessage()    {}
-func (*GetStreetViewResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_89fdd0f41cb65e4f, []int{1}
+func (m *StreetViewApiKeyUsage) Reset()         { *m = StreetViewApiKeyUsage{} }
+func (m *StreetViewApiKeyUsage) String() string { return proto.CompactTextString(m) }
+func (*StreetViewApiKeyUsage) ProtoMessage()    {}
+func (*StreetViewApiKeyUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89fdd0f41cb65e4f, []int{12}
 }
 
-func (m *GetStreetViewResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetStreetViewResponse.Unmarshal(m, b)
+func (m *StreetViewApiKeyUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreetViewApiKeyUsage.Unmarshal(m, b)
 }
-func (m *GetStreetViewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetStreetViewResponse.Marshal(b, m, deterministic)
+func (m *StreetViewApiKeyUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreetViewApiKeyUsage.Marshal(b, m, deterministic)
 }
-func (m *GetStreetViewResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetStreetViewResponse.Merge(m, src)
+func (m *StreetViewApiKeyUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreetViewApiKeyUsage.Merge(m, src)
 }
-func (m *GetStreetViewResponse) XXX_Size() int {
-	return xxx_messageInfo_GetStreetViewResponse.Size(m)
+func (m *StreetViewApiKeyUsage) XXX_Size() int {
+	return xxx_messageInfo_StreetViewApiKeyUsage.Size(m)
 }
-func (m *GetStreetViewResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetStreetViewResponse.DiscardUnknown(m)
+func (m *StreetViewApiKeyUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreetViewApiKeyUsage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetStreetViewResponse proto.InternalMessageInfo
+var xxx_messageInfo_StreetViewApiKeyUsage proto.InternalMessageInfo
 
-func (m *GetStreetViewResponse) GetImage() []byte {
+func (m *StreetViewApiKeyUsage) GetId() string {
 	if m != nil {
-		return m.Image
+		return m.Id
 	}
-	return nil
+	return ""
+}
+
+func (m *StreetViewApiKeyUsage) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *StreetViewApiKeyUsage) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *StreetViewApiKeyUsage) GetBillableRequests() uint64 {
+	if m != nil {
+		return m.BillableRequests
+	}
+	return 0
+}
+
+func (m *StreetViewApiKeyUsage) GetRefusals() uint64 {
+	if m != nil {
+		return m.Refusals
+	}
+	return 0
+}
+
+func (m *StreetViewApiKeyUsage) GetQuarantinedUntil() string {
+	if m != nil {
+		return m.QuarantinedUntil
+	}
+	return ""
 }
 
 func init() {
+	proto.RegisterEnum("v1.ImageSource", ImageSource_name, ImageSource_value)
 	proto.RegisterType((*GetStreetViewRequest)(nil), "v1.GetStreetViewRequest")
 	proto.RegisterType((*GetStreetViewResponse)(nil), "v1.GetStreetViewResponse")
+	proto.RegisterType((*GetStreetViewImagesRequest)(nil), "v1.GetStreetViewImagesRequest")
+	proto.RegisterType((*StreetViewLocation)(nil), "v1.StreetViewLocation")
+	proto.RegisterType((*GetStreetViewImagesResponse)(nil), "v1.GetStreetViewImagesResponse")
+	proto.RegisterType((*StreetViewImageResult)(nil), "v1.StreetViewImageResult")
+	proto.RegisterType((*GetStreetViewMetadataRequest)(nil), "v1.GetStreetViewMetadataRequest")
+	proto.RegisterType((*GetStreetViewMetadataResponse)(nil), "v1.GetStreetViewMetadataResponse")
+	proto.RegisterType((*GetStreetViewPanoRequest)(nil), "v1.GetStreetViewPanoRequest")
+	proto.RegisterType((*GetStreetViewPanoramaRequest)(nil), "v1.GetStreetViewPanoramaRequest")
+	proto.RegisterType((*GetStreetViewUsageRequest)(nil), "v1.GetStreetViewUsageRequest")
+	proto.RegisterType((*GetStreetViewUsageResponse)(nil), "v1.GetStreetViewUsageResponse")
+	proto.RegisterType((*StreetViewApiKeyUsage)(nil), "v1.StreetViewApiKeyUsage")
 }
 
 func init() { proto.RegisterFile("api/proto/v1/service.proto", fileDescriptor_89fdd0f41cb65e4f) }
 
 var fileDescriptor_89fdd0f41cb65e4f = []byte{
-	// 207 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xcf, 0x6a, 0x84, 0x30,
-	0x10, 0xc6, 0xab, 0xa5, 0xa5, 0x0e, 0xed, 0xa1, 0x83, 0x05, 0x2b, 0x3d, 0x88, 0xf4, 0xe0, 0xa5,
-	0x8a, 0xed, 0x43, 0x14, 0xe9, 0x2d, 0x42, 0xcf, 0xb5, 0x3a, 0x48, 0xc0, 0x35, 0x6e, 0x12, 0xe3,
-	0xeb, 0x2f, 0x26, 0xb0, 0x8b, 0x8b, 0xb7, 0x7c, 0x7f, 0xc8, 0x8f, 0x6f, 0x20, 0x6e, 0x26, 0x5e,
-	0x4c, 0x52, 0x68, 0x51, 0x98, 0xb2, 0x50, 0x24, 0x0d, 0x6f, 0x29, 0xb7, 0x06, 0xfa, 0xa6, 0x4c,
-	0x0d, 0x84, 0xdf, 0xa4, 0x6b, 0x2d, 0x89, 0xf4, 0x2f, 0xa7, 0x85, 0xd1, 0x71, 0x26, 0xa5, 0xf1,
-	0x1d, 0x9e, 0x5a, 0x21, 0x25, 0x0d, 0x8d, 0xe6, 0x62, 0xac, 0xba, 0xc8, 0x4b, 0xbc, 0x2c, 0x60,
-	0x5b, 0x13, 0x63, 0x78, 0x58, 0xdf, 0x7a, 0xee, 0x28, 0xf2, 0x13, 0x2f, 0xf3, 0xd9, 0x59, 0xe3,
-	0x1b, 0x04, 0x83, 0x18, 0x7b, 0x17, 0xde, 0xda, 0xf0, 0x62, 0xa4, 0x1f, 0xf0, 0x72, 0xc5, 0x55,
-	0x93, 0x18, 0x15, 0x61, 0x08, 0x77, 0xfc, 0xd0, 0xf4, 0x64, 0x81, 0x8f, 0xcc, 0x89, 0xcf, 0x3f,
-	0x78, 0x76, 0x5d, 0xc3, 0x69, 0xa9, 0xdd, 0x0a, 0xfc, 0x01, 0xdc, 0xfc, 0x51, 0xad, 0x55, 0x8c,
-	0x72, 0x53, 0xe6, 0x7b, 0x9b, 0xe2, 0xd7, 0x9d, 0xc4, 0x51, 0xd3, 0x9b, 0xff, 0x7b, 0x7b, 0x93,
-	0xaf, 0x53, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5c, 0xb6, 0xbd, 0xc5, 0x31, 0x01, 0x00, 0x00,
+	// 968 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x0e, 0x25, 0xea, 0x6f, 0x54, 0xa7, 0xee, 0x36, 0x32, 0x68, 0x45, 0x71, 0x55, 0xa1, 0x40,
+	0x84, 0x1c, 0x64, 0x58, 0x4e, 0x1f, 0xc0, 0x40, 0x9a, 0x42, 0x48, 0x0a, 0x17, 0xeb, 0xc8, 0xe8,
+	0xa9, 0xc0, 0x4a, 0x5c, 0x4b, 0x0b, 0xd0, 0x5c, 0x66, 0x77, 0x29, 0x35, 0x0f, 0x51, 0xa0, 0xe8,
+	0x03, 0xf4, 0x05, 0xfa, 0x30, 0x3d, 0xf6, 0xd4, 0x63, 0xdf, 0xa3, 0xd8, 0x5d, 0x4a, 0x32, 0x45,
+	0x4a, 0x95, 0x74, 0x08, 0x72, 0xe3, 0xcc, 0x7c, 0x33, 0x9c, 0x9f, 0x6f, 0x67, 0x49, 0x68, 0x92,
+	0x88, 0x9d, 0x47, 0x82, 0x2b, 0x7e, 0x3e, 0xbb, 0x38, 0x97, 0x54, 0xcc, 0xd8, 0x98, 0xf6, 0x8c,
+	0x02, 0x15, 0x66, 0x17, 0xcd, 0xb3, 0x09, 0xe7, 0x93, 0x80, 0x5a, 0xc8, 0x28, 0xbe, 0x3b, 0x9f,
+	0x0b, 0x12, 0x45, 0x54, 0x48, 0x8b, 0xe9, 0xfc, 0xea, 0xc2, 0x93, 0xef, 0xa9, 0xba, 0x51, 0x82,
+	0x52, 0x75, 0xcb, 0xe8, 0x1c, 0xd3, 0xf7, 0x31, 0x95, 0x0a, 0x7d, 0x03, 0x47, 0x63, 0x2e, 0x04,
+	0x0d, 0x88, 0x62, 0x3c, 0x1c, 0xf8, 0x9e, 0xd3, 0x76, 0xba, 0x35, 0x9c, 0x56, 0xa2, 0x26, 0x54,
+	0xf5, 0xb3, 0x8a, 0x7d, 0xea, 0x15, 0xda, 0x4e, 0xb7, 0x80, 0x97, 0x32, 0x6a, 0x41, 0x2d, 0xe0,
+	0xe1, 0xc4, 0x1a, 0x8b, 0xc6, 0xb8, 0x52, 0xa0, 0x6f, 0xa1, 0x32, 0xa5, 0xc4, 0x67, 0xe1, 0xc4,
+	0x73, 0xdb, 0x4e, 0xb7, 0xde, 0x7f, 0xda, 0xb3, 0xa9, 0xf6, 0x16, 0xa9, 0xf6, 0x5e, 0x07, 0x9c,
+	0xa8, 0x5b, 0x12, 0xc4, 0x14, 0x2f, 0xb0, 0xe8, 0x02, 0x4a, 0x11, 0x53, 0xe3, 0xa9, 0x57, 0xfa,
+	0x7f, 0x27, 0x8b, 0x44, 0x3d, 0x28, 0xde, 0xf1, 0x99, 0x57, 0x36, 0x0e, 0xad, 0x8c, 0xc3, 0x70,
+	0x10, 0xaa, 0xcb, 0xbe, 0xf5, 0xd0, 0x40, 0xf4, 0x12, 0xca, 0x82, 0xf8, 0x2c, 0x96, 0x5e, 0x65,
+	0x07, 0x97, 0x04, 0x8b, 0x9e, 0x43, 0x59, 0xf2, 0x58, 0x8c, 0xa9, 0x57, 0x6d, 0x3b, 0xdd, 0xc7,
+	0xfd, 0xcf, 0x7b, 0xb3, 0x8b, 0xde, 0xe0, 0x9e, 0x4c, 0xe8, 0x8d, 0x51, 0xe3, 0xc4, 0x8c, 0xfa,
+	0x50, 0x9a, 0x33, 0x5f, 0x4d, 0xbd, 0xda, 0x0e, 0xd1, 0x2d, 0x54, 0xa7, 0x34, 0xa5, 0x6c, 0x32,
+	0x55, 0x1e, 0xec, 0x92, 0x92, 0xc5, 0xea, 0x37, 0xc9, 0x31, 0x09, 0xa8, 0x57, 0xdf, 0xe5, 0x4d,
+	0x06, 0xda, 0x19, 0x40, 0x63, 0x8d, 0x0e, 0x32, 0xe2, 0xa1, 0xa4, 0xe8, 0x09, 0x94, 0x98, 0xae,
+	0xc6, 0xf0, 0xe0, 0x33, 0x6c, 0x05, 0x3d, 0xff, 0x48, 0xf0, 0x19, 0xf3, 0xa9, 0x30, 0xf3, 0xaf,
+	0xe1, 0xa5, 0xdc, 0xf9, 0x05, 0x9a, 0xa9, 0x50, 0xa6, 0x19, 0x72, 0x3f, 0x7e, 0xbd, 0xd4, 0x1c,
+	0x1a, 0x1b, 0x49, 0x7a, 0x85, 0x76, 0xb1, 0x5b, 0xef, 0x9f, 0xe8, 0xc6, 0xae, 0xa2, 0xbe, 0x4d,
+	0xcc, 0x78, 0x05, 0xec, 0xfc, 0x5b, 0x04, 0x94, 0x45, 0xa4, 0xc8, 0xea, 0x6c, 0x23, 0x6b, 0x61,
+	0x0b, 0x59, 0x8b, 0x87, 0x90, 0xd5, 0xdd, 0x97, 0xac, 0xa5, 0xfd, 0xc9, 0x5a, 0x3e, 0x88, 0xac,
+	0x95, 0x1d, 0xc9, 0x5a, 0x3d, 0x84, 0xac, 0xb5, 0x43, 0xc8, 0x0a, 0xbb, 0x93, 0x15, 0xc3, 0xd3,
+	0x5c, 0x86, 0x25, 0x94, 0xbd, 0x84, 0x8a, 0xa0, 0x32, 0x0e, 0x94, 0xf4, 0x1c, 0x43, 0x9d, 0xd3,
+	0x34, 0x75, 0x0c, 0x1c, 0x1b, 0x04, 0x5e, 0x20, 0x3b, 0x12, 0x1a, 0xb9, 0x88, 0x0d, 0x07, 0x00,
+	0x81, 0x3b, 0xe6, 0x09, 0x65, 0x8e, 0xb0, 0x79, 0xd6, 0x48, 0x2a, 0x04, 0x17, 0x86, 0x2b, 0x35,
+	0x6c, 0x85, 0xd4, 0x51, 0x71, 0xd7, 0x8e, 0xca, 0x3f, 0x0e, 0xb4, 0x52, 0x95, 0xfc, 0x40, 0x15,
+	0xf1, 0x89, 0x22, 0x1f, 0x6b, 0x1b, 0xaf, 0x68, 0xe4, 0x1e, 0x44, 0xa3, 0xd2, 0x56, 0x1a, 0x75,
+	0xfe, 0x76, 0xe0, 0xd9, 0x86, 0xfa, 0x92, 0x59, 0x9d, 0x40, 0x59, 0x2a, 0xa2, 0x62, 0x99, 0x54,
+	0x96, 0x48, 0x5a, 0x1f, 0x91, 0x90, 0x0f, 0xfc, 0x64, 0xbd, 0x24, 0x92, 0xee, 0xbb, 0x4f, 0x14,
+	0x4d, 0x5a, 0x6c, 0x9e, 0x75, 0x89, 0x63, 0x1e, 0x7d, 0x10, 0x86, 0x7b, 0xb6, 0xc5, 0x2b, 0x45,
+	0xaa, 0x39, 0x3a, 0x5d, 0x67, 0x53, 0x73, 0xca, 0xc6, 0xb8, 0x52, 0xa4, 0x26, 0x57, 0x59, 0x9b,
+	0xdc, 0x6f, 0x45, 0xf0, 0x52, 0x95, 0xfd, 0x48, 0x42, 0xbe, 0xdf, 0xd4, 0x36, 0x95, 0xf8, 0xe9,
+	0x2e, 0x9d, 0xe5, 0x56, 0x28, 0x1f, 0xb2, 0x15, 0x2a, 0x87, 0x6c, 0x85, 0xea, 0xee, 0x5b, 0xe1,
+	0xf7, 0x22, 0xb4, 0x32, 0x23, 0x11, 0xe4, 0xfe, 0xa3, 0x1d, 0x26, 0xcd, 0xe5, 0x80, 0x8d, 0xa9,
+	0x3d, 0x4c, 0x47, 0x38, 0x91, 0x56, 0x6d, 0x2b, 0x1d, 0xd2, 0xb6, 0xf2, 0x1e, 0x6d, 0x5b, 0x72,
+	0xa0, 0xb2, 0x33, 0x07, 0x56, 0x1b, 0xa0, 0x7a, 0xd0, 0x06, 0xa8, 0x6d, 0xdf, 0x00, 0x57, 0x70,
+	0x9a, 0x9a, 0xc9, 0x50, 0x9a, 0xcd, 0xba, 0xc7, 0x40, 0x3a, 0x7f, 0x3a, 0xd0, 0xcc, 0x8b, 0xb1,
+	0xfa, 0x40, 0xb9, 0xe7, 0xa1, 0x9a, 0x26, 0xce, 0x56, 0x40, 0x2f, 0xe0, 0x78, 0xc4, 0x82, 0x80,
+	0x8c, 0x82, 0xc5, 0xdb, 0xa4, 0x99, 0xa6, 0x8b, 0x33, 0x7a, 0x3d, 0xb7, 0x51, 0xec, 0x4f, 0xa8,
+	0x32, 0x23, 0x75, 0x71, 0x22, 0xe9, 0x7b, 0x84, 0x44, 0xec, 0x0d, 0xfd, 0xa0, 0x07, 0x9a, 0x73,
+	0x8f, 0x5c, 0x19, 0xa3, 0xcd, 0x66, 0x81, 0xec, 0xfc, 0xe5, 0x40, 0x23, 0x17, 0x82, 0x1e, 0x43,
+	0x81, 0x2d, 0x4a, 0x2c, 0x30, 0x73, 0xfe, 0xe7, 0x76, 0xc4, 0xf6, 0x12, 0x49, 0x24, 0x4d, 0x40,
+	0xb1, 0x48, 0xd9, 0x26, 0xb4, 0x94, 0x73, 0xcb, 0x72, 0x37, 0x94, 0x65, 0xe2, 0xdc, 0xc5, 0x92,
+	0x04, 0xd2, 0x2b, 0x2d, 0xe2, 0x58, 0x59, 0xc7, 0x79, 0x1f, 0x13, 0x41, 0x42, 0xc5, 0x42, 0xea,
+	0x0f, 0x43, 0xc5, 0x02, 0x43, 0xb4, 0x1a, 0xce, 0xe8, 0x5f, 0x3c, 0x87, 0xfa, 0x83, 0xc9, 0xa2,
+	0x3a, 0x54, 0x5e, 0x7d, 0xf7, 0xfa, 0x6a, 0xf8, 0xf6, 0xdd, 0xf1, 0x23, 0x2d, 0x5c, 0x0f, 0xdf,
+	0xbd, 0xba, 0xbe, 0xc6, 0xc7, 0x4e, 0xff, 0x0f, 0x17, 0xbe, 0xb0, 0xa5, 0xcf, 0x18, 0x9d, 0xdf,
+	0xd8, 0x7f, 0x12, 0xf4, 0x06, 0x50, 0xf6, 0xb2, 0x46, 0x9e, 0x6e, 0x65, 0xde, 0x0f, 0x48, 0xf3,
+	0x34, 0xc7, 0x62, 0x47, 0xdd, 0x79, 0x84, 0x7e, 0x82, 0x2f, 0xb3, 0xc1, 0x24, 0x3a, 0xcb, 0xf8,
+	0xa4, 0x3e, 0x3a, 0x9b, 0x5f, 0x6d, 0xb4, 0x2f, 0x23, 0xff, 0x0c, 0x8d, 0xdc, 0x9b, 0x0a, 0xb5,
+	0x33, 0xbe, 0x6b, 0x97, 0x74, 0xf3, 0xeb, 0x2d, 0x88, 0x65, 0xfc, 0x1b, 0x38, 0xc9, 0x2c, 0x27,
+	0xdb, 0x8a, 0x56, 0xc6, 0xfd, 0xc1, 0x5d, 0xb2, 0xbd, 0x1d, 0xb7, 0xd0, 0xc8, 0x38, 0xea, 0x8d,
+	0x97, 0x93, 0xf4, 0xda, 0x32, 0xdc, 0x1e, 0x77, 0xb8, 0x36, 0x33, 0x4b, 0xe0, 0x67, 0x19, 0x97,
+	0x87, 0xa7, 0xb9, 0x79, 0xb6, 0xc9, 0xbc, 0x08, 0x3b, 0x2a, 0x9b, 0x9d, 0x72, 0xf9, 0xdf, 0x00,
+	0x47, 0xd3, 0x55, 0xab, 0xbd, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -153,6 +1129,11 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type StreetviewServiceClient interface {
 	GetStreetViewImage(ctx context.Context, in *GetStreetViewRequest, opts ...grpc.CallOption) (*GetStreetViewResponse, error)
+	GetStreetViewImages(ctx context.Context, in *GetStreetViewImagesRequest, opts ...grpc.CallOption) (*GetStreetViewImagesResponse, error)
+	GetStreetViewMetadata(ctx context.Context, in *GetStreetViewMetadataRequest, opts ...grpc.CallOption) (*GetStreetViewMetadataResponse, error)
+	GetStreetViewPanoImage(ctx context.Context, in *GetStreetViewPanoRequest, opts ...grpc.CallOption) (*GetStreetViewResponse, error)
+	GetStreetViewPanorama(ctx context.Context, in *GetStreetViewPanoramaRequest, opts ...grpc.CallOption) (*GetStreetViewResponse, error)
+	GetStreetViewUsage(ctx context.Context, in *GetStreetViewUsageRequest, opts ...grpc.CallOption) (*GetStreetViewUsageResponse, error)
 }
 
 type streetviewServiceClient struct {
@@ -172,9 +1153,59 @@ func (c *streetviewServiceClient) GetStreetViewImage(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *streetviewServiceClient) GetStreetViewImages(ctx context.Context, in *GetStreetViewImagesRequest, opts ...grpc.CallOption) (*GetStreetViewImagesResponse, error) {
+	out := new(GetStreetViewImagesResponse)
+	err := c.cc.Invoke(ctx, "/v1.StreetviewService/GetStreetViewImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streetviewServiceClient) GetStreetViewMetadata(ctx context.Context, in *GetStreetViewMetadataRequest, opts ...grpc.CallOption) (*GetStreetViewMetadataResponse, error) {
+	out := new(GetStreetViewMetadataResponse)
+	err := c.cc.Invoke(ctx, "/v1.StreetviewService/GetStreetViewMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streetviewServiceClient) GetStreetViewPanoImage(ctx context.Context, in *GetStreetViewPanoRequest, opts ...grpc.CallOption) (*GetStreetViewResponse, error) {
+	out := new(GetStreetViewResponse)
+	err := c.cc.Invoke(ctx, "/v1.StreetviewService/GetStreetViewPanoImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streetviewServiceClient) GetStreetViewPanorama(ctx context.Context, in *GetStreetViewPanoramaRequest, opts ...grpc.CallOption) (*GetStreetViewResponse, error) {
+	out := new(GetStreetViewResponse)
+	err := c.cc.Invoke(ctx, "/v1.StreetviewService/GetStreetViewPanorama", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streetviewServiceClient) GetStreetViewUsage(ctx context.Context, in *GetStreetViewUsageRequest, opts ...grpc.CallOption) (*GetStreetViewUsageResponse, error) {
+	out := new(GetStreetViewUsageResponse)
+	err := c.cc.Invoke(ctx, "/v1.StreetviewService/GetStreetViewUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StreetviewServiceServer is the server API for StreetviewService service.
 type StreetviewServiceServer interface {
 	GetStreetViewImage(context.Context, *GetStreetViewRequest) (*GetStreetViewResponse, error)
+	GetStreetViewImages(context.Context, *GetStreetViewImagesRequest) (*GetStreetViewImagesResponse, error)
+	GetStreetViewMetadata(context.Context, *GetStreetViewMetadataRequest) (*GetStreetViewMetadataResponse, error)
+	GetStreetViewPanoImage(context.Context, *GetStreetViewPanoRequest) (*GetStreetViewResponse, error)
+	GetStreetViewPanorama(context.Context, *GetStreetViewPanoramaRequest) (*GetStreetViewResponse, error)
+	GetStreetViewUsage(context.Context, *GetStreetViewUsageRequest) (*GetStreetViewUsageResponse, error)
 }
 
 func RegisterStreetviewServiceServer(s *grpc.Server, srv StreetviewServiceServer) {
@@ -199,6 +1230,96 @@ func _StreetviewService_GetStreetViewImage_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StreetviewService_GetStreetViewImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreetViewImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetviewServiceServer).GetStreetViewImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.StreetviewService/GetStreetViewImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetviewServiceServer).GetStreetViewImages(ctx, req.(*GetStreetViewImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreetviewService_GetStreetViewMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreetViewMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetviewServiceServer).GetStreetViewMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.StreetviewService/GetStreetViewMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetviewServiceServer).GetStreetViewMetadata(ctx, req.(*GetStreetViewMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreetviewService_GetStreetViewPanoImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreetViewPanoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetviewServiceServer).GetStreetViewPanoImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.StreetviewService/GetStreetViewPanoImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetviewServiceServer).GetStreetViewPanoImage(ctx, req.(*GetStreetViewPanoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreetviewService_GetStreetViewPanorama_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreetViewPanoramaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetviewServiceServer).GetStreetViewPanorama(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.StreetviewService/GetStreetViewPanorama",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetviewServiceServer).GetStreetViewPanorama(ctx, req.(*GetStreetViewPanoramaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreetviewService_GetStreetViewUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreetViewUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetviewServiceServer).GetStreetViewUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.StreetviewService/GetStreetViewUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetviewServiceServer).GetStreetViewUsage(ctx, req.(*GetStreetViewUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _StreetviewService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.StreetviewService",
 	HandlerType: (*StreetviewServiceServer)(nil),
@@ -207,6 +1328,26 @@ var _StreetviewService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStreetViewImage",
 			Handler:    _StreetviewService_GetStreetViewImage_Handler,
 		},
+		{
+			MethodName: "GetStreetViewImages",
+			Handler:    _StreetviewService_GetStreetViewImages_Handler,
+		},
+		{
+			MethodName: "GetStreetViewMetadata",
+			Handler:    _StreetviewService_GetStreetViewMetadata_Handler,
+		},
+		{
+			MethodName: "GetStreetViewPanoImage",
+			Handler:    _StreetviewService_GetStreetViewPanoImage_Handler,
+		},
+		{
+			MethodName: "GetStreetViewPanorama",
+			Handler:    _StreetviewService_GetStreetViewPanorama_Handler,
+		},
+		{
+			MethodName: "GetStreetViewUsage",
+			Handler:    _StreetviewService_GetStreetViewUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/v1/service.proto",
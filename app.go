@@ -3,8 +3,13 @@ package main
 import (
 	"app/config"
 	"app/src"
+	"app/src/StreetViewImage/Application/QueryHandler"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Cache"
 	"app/src/StreetViewImage/Infrastructure/Logger"
 	"app/src/StreetViewImage/Infrastructure/Server"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/j7mbo/goenvconfig"
@@ -22,10 +27,14 @@ Ensure these are pointers as the call to the goenvconfig lib parser requires poi
 [...] instead of [] ensures we get a fixed-size array instead of a slice.
 */
 var configToShareWithInjector = [...]interface{}{
+	&config.CacheConfiguration{},
 	&config.ElasticSearchConfiguration{},
 	&config.GrpcServerConfiguration{},
+	&config.HttpClientConfiguration{},
+	&config.MapillaryConfiguration{},
 	&config.RedisConfiguration{},
 	&config.StreetViewApiConfiguration{},
+	&config.WebServerConfiguration{},
 }
 
 /* Here we golang! */
@@ -37,6 +46,17 @@ func main() {
 	shareInjector(ij)
 	configureLogger(ij)
 	delegateGrpcMapper(ij)
+	delegateHttpMapper(ij)
+	delegateApiStatus(ij)
+	delegateCircuitBreaker(ij)
+	delegateInFlightFetches(ij)
+	delegateHttpClient(ij)
+	delegateApiKeys(ij)
+	delegateRedisClientFactory(ij)
+	delegateStreetViewImages(ij)
+
+	/* Webserver for plain http image requests, next to the GRPC one. */
+	go ij.Make("app/src/StreetViewImage/Infrastructure/Server.HttpServer").(Server.HttpServer).Run()
 
 	/* Webserver (for GRPC actually). */
 	ij.Make("app/src/StreetViewImage/Infrastructure/Server.GrpcServer").(Server.GrpcServer).Run()
@@ -108,3 +128,141 @@ func delegateGrpcMapper(injector Goij.Injector) {
 		)
 	})
 }
+
+/* delegateHttpMapper delegates the initialisation of the controller interface to the Server.HttpErrorMapper. */
+func delegateHttpMapper(injector Goij.Injector) {
+	injector.Delegate("app/src/StreetViewImage/Presentation/Controller.HttpErrorMapper", func() Server.HttpErrorMapper {
+		return Server.NewHttpErrorMapper(
+			injector.Make("app/src/StreetViewImage/Presentation/Controller.GrpcErrorMapper").(Server.GrpcErrorMapper),
+		)
+	})
+}
+
+/*
+delegateApiStatus delegates every request for an ApiClient.StreetViewApiStatus to the same instance, so the outcome of
+every call made by any api client is available to the health service.
+*/
+func delegateApiStatus(injector Goij.Injector) {
+	apiStatus := ApiClient.NewStreetViewApiStatus()
+
+	injector.Delegate(
+		"app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiStatus",
+		func() ApiClient.StreetViewApiStatus { return apiStatus },
+	)
+}
+
+/*
+delegateCircuitBreaker delegates every request for an ApiClient.StreetViewApiCircuitBreaker to the same instance, so
+that the failures of every api client count towards opening it and every api client fails fast once it is open.
+*/
+func delegateCircuitBreaker(injector Goij.Injector) {
+	breaker := ApiClient.NewStreetViewApiCircuitBreaker(
+		*injector.Make("app/config.StreetViewApiConfiguration").(*config.StreetViewApiConfiguration),
+		injector.Make("app/src/StreetViewImage/Infrastructure/Logger.LoggingStrategy").(*Logger.LoggingStrategy),
+	)
+
+	injector.Delegate(
+		"app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiCircuitBreaker",
+		func() ApiClient.StreetViewApiCircuitBreaker { return breaker },
+	)
+}
+
+/*
+delegateInFlightFetches delegates every request for a QueryHandler.InFlightImageFetches to the same instance, so that
+concurrent requests for the same image are coalesced into a single api call across every handler.
+*/
+func delegateInFlightFetches(injector Goij.Injector) {
+	inFlightFetches := QueryHandler.NewInFlightImageFetches()
+
+	injector.Delegate(
+		"app/src/StreetViewImage/Application/QueryHandler.InFlightImageFetches",
+		func() QueryHandler.InFlightImageFetches { return inFlightFetches },
+	)
+}
+
+/*
+delegateHttpClient delegates every request for an ApiClient.HttpClient to the same instance, so that every api client
+re-uses the same pool of kept alive connections.
+*/
+func delegateHttpClient(injector Goij.Injector) {
+	httpClient := ApiClient.NewHttpClient(
+		*injector.Make("app/config.HttpClientConfiguration").(*config.HttpClientConfiguration),
+		injector.Make("app/src/StreetViewImage/Infrastructure/Logger.LoggingStrategy").(*Logger.LoggingStrategy),
+	)
+
+	injector.Delegate(
+		"app/src/StreetViewImage/Infrastructure/ApiClient.HttpClient",
+		func() ApiClient.HttpClient { return httpClient },
+	)
+}
+
+/*
+delegateApiKeys delegates every request for an ApiClient.StreetViewApiKeys to the same instance, so that the rotation,
+quarantines and counters of the api keys are shared by every api client and the usage handler.
+*/
+func delegateApiKeys(injector Goij.Injector) {
+	keys := ApiClient.NewStreetViewApiKeys(
+		*injector.Make("app/config.StreetViewApiConfiguration").(*config.StreetViewApiConfiguration),
+		injector.Make("app/src/StreetViewImage/Infrastructure/Logger.LoggingStrategy").(*Logger.LoggingStrategy),
+	)
+
+	injector.Delegate(
+		"app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiKeys",
+		func() ApiClient.StreetViewApiKeys { return keys },
+	)
+}
+
+/*
+delegateRedisClientFactory delegates every request for a Cache.RedisClientFactory to the same instance, so that the
+images, the rate limiter and the budget share the one connected client (and it's pool of connections).
+*/
+func delegateRedisClientFactory(injector Goij.Injector) {
+	redisClientFactory := Cache.NewRedisClientFactory(
+		*injector.Make("app/config.RedisConfiguration").(*config.RedisConfiguration),
+	)
+
+	injector.Delegate(
+		"app/src/StreetViewImage/Infrastructure/Cache.RedisClientFactory",
+		func() *Cache.RedisClientFactory { return redisClientFactory },
+	)
+}
+
+/*
+delegateStreetViewImages delegates every request for a Domain.StreetViewImages to the same Cache.TieredStreetViewImages
+of the configured tiers, so that the images held in memory (and the index of those on disk) and the counters of every
+tier are shared by every handler.
+*/
+func delegateStreetViewImages(injector Goij.Injector) {
+	cacheConfig := injector.Make("app/config.CacheConfiguration").(*config.CacheConfiguration)
+	logger := injector.Make("app/src/StreetViewImage/Infrastructure/Logger.LoggingStrategy").(*Logger.LoggingStrategy)
+
+	images, err := Cache.NewTieredStreetViewImages(*cacheConfig, func(name string) (Domain.StreetViewImages, error) {
+		switch name {
+		case Cache.StoreRedis:
+			return injector.Make(
+				"app/src/StreetViewImage/Infrastructure/Cache.RedisStreetViewImages",
+			).(*Cache.RedisStreetViewImages), nil
+		case Cache.StoreMemory:
+			return Cache.NewMemoryStreetViewImages(*cacheConfig, logger), nil
+		case Cache.StoreDisk:
+			diskImages := Cache.NewFilesystemStreetViewImages(*cacheConfig, logger)
+
+			return diskImages, diskImages.Open()
+		}
+
+		return nil, errors.New(fmt.Sprintf(
+			"unknown store, expected one of: '%s', '%s' or '%s'", Cache.StoreMemory, Cache.StoreRedis, Cache.StoreDisk,
+		))
+	}, logger)
+
+	if err != nil {
+		panic(err)
+	}
+
+	images.StartLoggingStats()
+
+	injector.Delegate(
+		"app/src/StreetViewImage/Domain.StreetViewImages",
+		func() Domain.StreetViewImages { return images },
+	)
+}
@@ -0,0 +1,43 @@
+package config
+
+/*
+CacheConfiguration contains the configuration of the stores images are cached in, in tiers: redis, shared between
+instances, the memory of the instance for single instance deployments and for when redis is unavailable, and the local
+disk of the instance for nodes without redis.
+*/
+type CacheConfiguration struct {
+	/* The comma separated stores images are cached in, read in order: any of "memory", "redis" and "disk". */
+	tiers string `env:"CACHE_TIERS" default:"redis"`
+	/* The comma separated tiers images are saved to, every one when empty, the others only being promoted to. */
+	writeTiers string `env:"CACHE_WRITE_TIERS"`
+	/* The seconds between logging the hits and misses of each tier, not logged when 0. */
+	statsInterval int `env:"CACHE_STATS_INTERVAL" default:"300"`
+	/* The bytes of images held in memory at most, beyond which the least recently used images are evicted. */
+	memoryMaxBytes int `env:"CACHE_MEMORY_MAX_BYTES" default:"67108864"`
+	/* The seconds an image is held in memory for. */
+	memoryExpiration int `env:"CACHE_MEMORY_EXPIRATION" default:"3600"`
+	/* The seconds a location without coverage is remembered for in memory, as with REDIS_NO_COVERAGE_EXPIRATION. */
+	memoryNoCoverageExpiration int `env:"CACHE_MEMORY_NO_COVERAGE_EXPIRATION" default:"86400"`
+	/* The directory images are stored in on disk, created if need be. */
+	diskDirectory string `env:"CACHE_DISK_DIRECTORY" default:"/var/cache/palmago-streetview"`
+	/* The bytes of images stored on disk at most, beyond which the least recently used images are removed. */
+	diskMaxBytes int `env:"CACHE_DISK_MAX_BYTES" default:"1073741824"`
+	/* The seconds an image is stored on disk for, as long as in redis by default. */
+	diskExpiration int `env:"CACHE_DISK_EXPIRATION" default:"4813200"`
+	/* The seconds a location without coverage is remembered for on disk, as with REDIS_NO_COVERAGE_EXPIRATION. */
+	diskNoCoverageExpiration int `env:"CACHE_DISK_NO_COVERAGE_EXPIRATION" default:"86400"`
+	/* The seconds between the removal of expired images, and of those beyond the max bytes, from disk. */
+	diskJanitorInterval int `env:"CACHE_DISK_JANITOR_INTERVAL" default:"60"`
+}
+
+func (c *CacheConfiguration) GetTiers() string                   { return c.tiers }
+func (c *CacheConfiguration) GetWriteTiers() string              { return c.writeTiers }
+func (c *CacheConfiguration) GetStatsInterval() int              { return c.statsInterval }
+func (c *CacheConfiguration) GetMemoryMaxBytes() int             { return c.memoryMaxBytes }
+func (c *CacheConfiguration) GetMemoryExpiration() int           { return c.memoryExpiration }
+func (c *CacheConfiguration) GetMemoryNoCoverageExpiration() int { return c.memoryNoCoverageExpiration }
+func (c *CacheConfiguration) GetDiskDirectory() string           { return c.diskDirectory }
+func (c *CacheConfiguration) GetDiskMaxBytes() int               { return c.diskMaxBytes }
+func (c *CacheConfiguration) GetDiskExpiration() int             { return c.diskExpiration }
+func (c *CacheConfiguration) GetDiskNoCoverageExpiration() int   { return c.diskNoCoverageExpiration }
+func (c *CacheConfiguration) GetDiskJanitorInterval() int        { return c.diskJanitorInterval }
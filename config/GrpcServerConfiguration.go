@@ -8,10 +8,13 @@ type GrpcServerConfiguration struct {
 	port       int    `env:"GRPC_SERVER_PORT" default:"4000"`
 	retryDelay int    `env:"GRPC_SERVER_RETRY_DELAY" default:"5"`
 	maxRetries int    `env:"GRPC_SERVER_MAX_RETRIES" default:"10"`
+	/* The number of seconds between each probe of the dependencies for the grpc health service. */
+	healthCheckInterval int `env:"GRPC_SERVER_HEALTH_CHECK_INTERVAL" default:"10"`
 }
 
-func (c *GrpcServerConfiguration) GetProtocol() string { return c.protocol }
-func (c *GrpcServerConfiguration) GetHost() string     { return c.host }
-func (c *GrpcServerConfiguration) GetPort() int        { return c.port }
-func (c *GrpcServerConfiguration) GetRetryDelay() int  { return c.retryDelay }
-func (c *GrpcServerConfiguration) GetMaxRetries() int  { return c.maxRetries }
+func (c *GrpcServerConfiguration) GetProtocol() string         { return c.protocol }
+func (c *GrpcServerConfiguration) GetHost() string             { return c.host }
+func (c *GrpcServerConfiguration) GetPort() int                { return c.port }
+func (c *GrpcServerConfiguration) GetRetryDelay() int          { return c.retryDelay }
+func (c *GrpcServerConfiguration) GetMaxRetries() int          { return c.maxRetries }
+func (c *GrpcServerConfiguration) GetHealthCheckInterval() int { return c.healthCheckInterval }
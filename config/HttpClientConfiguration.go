@@ -0,0 +1,33 @@
+package config
+
+/*
+HttpClientConfiguration contains the configuration of the http client shared by every call made to the imagery
+providers' apis, which keeps connections alive in a pool between requests.
+*/
+type HttpClientConfiguration struct {
+	/* The seconds a single attempt of a request may take overall, from dialing to reading the body. */
+	timeout int `env:"HTTP_CLIENT_TIMEOUT" default:"5"`
+	/* The seconds connecting to the api (or the proxy) may take. */
+	dialTimeout int `env:"HTTP_CLIENT_DIAL_TIMEOUT" default:"2"`
+	/* The seconds the TLS handshake may take. */
+	tlsHandshakeTimeout int `env:"HTTP_CLIENT_TLS_HANDSHAKE_TIMEOUT" default:"3"`
+	/* The seconds waited for the headers of the response once the request is written. */
+	responseHeaderTimeout int `env:"HTTP_CLIENT_RESPONSE_HEADER_TIMEOUT" default:"5"`
+	/* The idle connections kept alive in the pool per host, for the next requests to re-use. */
+	maxIdleConnections int `env:"HTTP_CLIENT_MAX_IDLE_CONNECTIONS_PER_HOST" default:"10"`
+	/* The seconds an idle connection is kept alive in the pool for. */
+	idleConnectionTimeout int `env:"HTTP_CLIENT_IDLE_CONNECTION_TIMEOUT" default:"90"`
+	/* The proxy every request is sent through, otherwise the HTTPS_PROXY / HTTP_PROXY / NO_PROXY env vars are used. */
+	proxyUrl string `env:"HTTP_CLIENT_PROXY_URL"`
+	/* The path to a PEM bundle of CA certificates to trust on top of the system's, such as an egress proxy's. */
+	caBundle string `env:"HTTP_CLIENT_CA_BUNDLE"`
+}
+
+func (c *HttpClientConfiguration) GetTimeout() int               { return c.timeout }
+func (c *HttpClientConfiguration) GetDialTimeout() int           { return c.dialTimeout }
+func (c *HttpClientConfiguration) GetTlsHandshakeTimeout() int   { return c.tlsHandshakeTimeout }
+func (c *HttpClientConfiguration) GetResponseHeaderTimeout() int { return c.responseHeaderTimeout }
+func (c *HttpClientConfiguration) GetMaxIdleConnections() int    { return c.maxIdleConnections }
+func (c *HttpClientConfiguration) GetIdleConnectionTimeout() int { return c.idleConnectionTimeout }
+func (c *HttpClientConfiguration) GetProxyUrl() string           { return c.proxyUrl }
+func (c *HttpClientConfiguration) GetCaBundle() string           { return c.caBundle }
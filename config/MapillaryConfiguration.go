@@ -0,0 +1,18 @@
+package config
+
+/*
+MapillaryConfiguration contains the configuration for use in calling the Mapillary API, when it is the configured
+imagery provider.
+
+See: https://www.mapillary.com/developer/api-documentation.
+*/
+type MapillaryConfiguration struct {
+	endpoint    string `env:"MAPILLARY_API_ENDPOINT" default:"https://graph.mapillary.com"`
+	accessToken string `env:"MAPILLARY_ACCESS_TOKEN"`
+	/* The radius in meters in which to search for the nearest image, unless a radius is requested. */
+	searchRadius int `env:"MAPILLARY_SEARCH_RADIUS" default:"50"`
+}
+
+func (c *MapillaryConfiguration) GetEndpoint() string    { return c.endpoint }
+func (c *MapillaryConfiguration) GetAccessToken() string { return c.accessToken }
+func (c *MapillaryConfiguration) GetSearchRadius() int   { return c.searchRadius }
@@ -6,9 +6,15 @@ type RedisConfiguration struct {
 	port       int    `env:"REDIS_port" default:"6379"`
 	retryDelay int    `env:"REDIS_RETRY_DELAY" default:"10"`
 	maxRetries int    `env:"REDIS_MAX_RETRIES" default:"5"`
+	/* The seconds to wait for a connection to, or a reply from redis, before the attempt is given up on. */
+	timeout int `env:"REDIS_TIMEOUT" default:"2"`
+	/* The seconds a location without coverage is remembered for, shorter than images as coverage gets added. */
+	noCoverageExpiration int `env:"REDIS_NO_COVERAGE_EXPIRATION" default:"86400"`
 }
 
-func (c *RedisConfiguration) GetHostname() string { return c.hostname }
-func (c *RedisConfiguration) GetPort() int        { return c.port }
-func (c *RedisConfiguration) GetRetryDelay() int  { return c.retryDelay }
-func (c *RedisConfiguration) GetMaxRetries() int  { return c.maxRetries }
+func (c *RedisConfiguration) GetHostname() string          { return c.hostname }
+func (c *RedisConfiguration) GetPort() int                 { return c.port }
+func (c *RedisConfiguration) GetRetryDelay() int           { return c.retryDelay }
+func (c *RedisConfiguration) GetMaxRetries() int           { return c.maxRetries }
+func (c *RedisConfiguration) GetTimeout() int              { return c.timeout }
+func (c *RedisConfiguration) GetNoCoverageExpiration() int { return c.noCoverageExpiration }
@@ -13,12 +13,60 @@ type StreetViewApiConfiguration struct {
 	apiKey     string `env:"STREETVIEW_API_KEY"`
 	maxRetries int    `env:"STREETVIEW_API_MAX_RETRIES" default:"10"`
 	retryDelay int    `env:"STREETVIEW_API_RETRY_DELAY" default:"5"`
+	/* The optional url-safe base64 secret used to sign requests, required by Google above certain volumes. */
+	signingSecret string `env:"STREETVIEW_API_SIGNING_SECRET"`
+	/* The maximum number of requests made to the API at the same time when fetching a batch of images. */
+	maxConcurrentRequests int `env:"STREETVIEW_API_MAX_CONCURRENT_REQUESTS" default:"5"`
+	/* The max scale (pixel density) images may be requested with, scale=2 is only permitted on some api plans. */
+	maxScale int `env:"STREETVIEW_API_MAX_SCALE" default:"1"`
+	/* The consecutive failed calls after which the circuit breaker opens and fails fast, less than 1 disables it. */
+	breakerFailures int `env:"STREETVIEW_API_BREAKER_FAILURE_THRESHOLD" default:"5"`
+	/* The seconds an open circuit breaker waits before letting a trial call through to see if the api recovered. */
+	breakerCoolDown int `env:"STREETVIEW_API_BREAKER_COOL_DOWN" default:"30"`
+	/* The successful trial calls needed for a half-open circuit breaker to close again. */
+	breakerSuccesses int `env:"STREETVIEW_API_BREAKER_SUCCESS_THRESHOLD" default:"2"`
+	/* The calls per second made to the api across every instance, shared through redis, less than 1 disables it. */
+	rateLimit int `env:"STREETVIEW_API_RATE_LIMIT" default:"50"`
+	/* The calls that can be made in a burst above the rate limit after a quiet period. */
+	rateLimitBurst int `env:"STREETVIEW_API_RATE_LIMIT_BURST" default:"50"`
+	/* The seconds a call is queued waiting for the rate limit before failing instead. */
+	rateLimitMaxWait int `env:"STREETVIEW_API_RATE_LIMIT_MAX_WAIT" default:"5"`
+	/* The billable image requests permitted per calendar month across every instance, less than 1 means unlimited. */
+	monthlyBudget int `env:"STREETVIEW_API_MONTHLY_BUDGET" default:"0"`
+	/* Comma separated percentages of the monthly budget at which a warning is logged when reached. */
+	budgetWarnings string `env:"STREETVIEW_API_BUDGET_WARNING_THRESHOLDS" default:"50,80,95"`
+	/* Comma separated imagery providers to try in turn: google and / or mapillary (see MapillaryConfiguration). */
+	providers string `env:"STREETVIEW_API_PROVIDERS" default:"google"`
+	/* The seconds the delay between retries, doubling from the retry delay, is capped at. Also the longest Retry-After. */
+	maxRetryDelay int `env:"STREETVIEW_API_MAX_RETRY_DELAY" default:"30"`
+	/*
+		Comma separated keys to rotate between, each as key:weight or key:weight:signing-secret when the key belongs to
+		a project with a different signing secret. The api key above is used alone when there are none.
+	*/
+	apiKeys string `env:"STREETVIEW_API_KEYS"`
+	/* The seconds a key is not used for after google refused it as over it's quota or denied. */
+	apiKeyQuarantine int `env:"STREETVIEW_API_KEY_QUARANTINE" default:"300"`
 }
 
-func (c *StreetViewApiConfiguration) GetEndpoint() string { return c.endpoint }
-func (c *StreetViewApiConfiguration) GetHeight() int      { return c.height }
-func (c *StreetViewApiConfiguration) GetWidth() int       { return c.width }
-func (c *StreetViewApiConfiguration) GetFov() int         { return c.fov }
-func (c *StreetViewApiConfiguration) GetApiKey() string   { return c.apiKey }
-func (c *StreetViewApiConfiguration) GetMaxRetries() int  { return c.maxRetries }
-func (c *StreetViewApiConfiguration) GetRetryDelay() int  { return c.retryDelay }
+func (c *StreetViewApiConfiguration) GetEndpoint() string           { return c.endpoint }
+func (c *StreetViewApiConfiguration) GetHeight() int                { return c.height }
+func (c *StreetViewApiConfiguration) GetWidth() int                 { return c.width }
+func (c *StreetViewApiConfiguration) GetFov() int                   { return c.fov }
+func (c *StreetViewApiConfiguration) GetApiKey() string             { return c.apiKey }
+func (c *StreetViewApiConfiguration) GetSigningSecret() string      { return c.signingSecret }
+func (c *StreetViewApiConfiguration) GetMaxRetries() int            { return c.maxRetries }
+func (c *StreetViewApiConfiguration) GetRetryDelay() int            { return c.retryDelay }
+func (c *StreetViewApiConfiguration) GetMaxConcurrentRequests() int { return c.maxConcurrentRequests }
+func (c *StreetViewApiConfiguration) GetMaxScale() int              { return c.maxScale }
+func (c *StreetViewApiConfiguration) GetBreakerFailures() int       { return c.breakerFailures }
+func (c *StreetViewApiConfiguration) GetBreakerCoolDown() int       { return c.breakerCoolDown }
+func (c *StreetViewApiConfiguration) GetBreakerSuccesses() int      { return c.breakerSuccesses }
+func (c *StreetViewApiConfiguration) GetRateLimit() int             { return c.rateLimit }
+func (c *StreetViewApiConfiguration) GetRateLimitBurst() int        { return c.rateLimitBurst }
+func (c *StreetViewApiConfiguration) GetRateLimitMaxWait() int      { return c.rateLimitMaxWait }
+func (c *StreetViewApiConfiguration) GetMonthlyBudget() int         { return c.monthlyBudget }
+func (c *StreetViewApiConfiguration) GetBudgetWarnings() string     { return c.budgetWarnings }
+func (c *StreetViewApiConfiguration) GetProviders() string          { return c.providers }
+func (c *StreetViewApiConfiguration) GetMaxRetryDelay() int         { return c.maxRetryDelay }
+func (c *StreetViewApiConfiguration) GetApiKeys() string            { return c.apiKeys }
+func (c *StreetViewApiConfiguration) GetApiKeyQuarantine() int      { return c.apiKeyQuarantine }
@@ -0,0 +1,14 @@
+package config
+
+/* WebServerConfiguration contains the configuration for use in starting the http server, next to the gRPC server. */
+type WebServerConfiguration struct {
+	/* If you're running this on localhost, not in docker, the server host should be: "". */
+	host string `env:"WEBSERVER_LISTEN_HOST" default:""`
+	port int    `env:"WEBSERVER_LISTEN_PORT" default:"8080"`
+	/* The number of seconds clients and CDNs may cache an image for, sent in the Cache-Control header. */
+	cacheMaxAge int `env:"WEBSERVER_CACHE_MAX_AGE" default:"86400"`
+}
+
+func (c *WebServerConfiguration) GetHost() string     { return c.host }
+func (c *WebServerConfiguration) GetPort() int        { return c.port }
+func (c *WebServerConfiguration) GetCacheMaxAge() int { return c.cacheMaxAge }
@@ -8,6 +8,10 @@ func GetConfigRegistry() (registry TypeRegistry.Registry) {
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/config.RedisConfiguration", Implementation: YGQkDJvA.RedisConfiguration{}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/config.GrpcServerConfiguration", Implementation: YGQkDJvA.GrpcServerConfiguration{}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/config.StreetViewApiConfiguration", Implementation: YGQkDJvA.StreetViewApiConfiguration{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/config.WebServerConfiguration", Implementation: YGQkDJvA.WebServerConfiguration{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/config.MapillaryConfiguration", Implementation: YGQkDJvA.MapillaryConfiguration{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/config.HttpClientConfiguration", Implementation: YGQkDJvA.HttpClientConfiguration{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/config.CacheConfiguration", Implementation: YGQkDJvA.CacheConfiguration{}})
 
 	return
 }
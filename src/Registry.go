@@ -7,6 +7,7 @@ import mKaXayJi "app/src/StreetViewImage/Application/QueryHandler"
 import GyZJpPBm "app/src/StreetViewImage/Domain"
 import olJUMOFZ "app/src/StreetViewImage/Infrastructure/ApiClient"
 import DpzQhmiZ "app/src/StreetViewImage/Infrastructure/Cache"
+import tnZlTvBi "app/src/StreetViewImage/Infrastructure/Health"
 import RKxnsxot "app/src/StreetViewImage/Infrastructure/Logger"
 import gbLwVnqJ "app/src/StreetViewImage/Infrastructure/Server"
 import PefLEOee "app/src/StreetViewImage/Presentation/Controller"
@@ -17,19 +18,75 @@ func GetRegistry() (registry TypeRegistry.Registry) {
 	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/Error.ApplicationError", Implementations: []interface{}{mGQzNMon.NewApplicationError}})
 	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewImage", Implementation: (*poXJtEkr.GetStreetViewImage)(nil)})
 	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewImage", Implementations: []interface{}{poXJtEkr.NewGetStreetViewImageQuery}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewImages", Implementation: (*poXJtEkr.GetStreetViewImages)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewImages", Implementations: []interface{}{poXJtEkr.NewGetStreetViewImagesQuery}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewMetadata", Implementation: (*poXJtEkr.GetStreetViewMetadata)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewMetadata", Implementations: []interface{}{poXJtEkr.NewGetStreetViewMetadataQuery}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewPanoImage", Implementation: (*poXJtEkr.GetStreetViewPanoImage)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewPanoImage", Implementations: []interface{}{poXJtEkr.NewGetStreetViewPanoImageQuery}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewPanorama", Implementation: (*poXJtEkr.GetStreetViewPanorama)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewPanorama", Implementations: []interface{}{poXJtEkr.NewGetStreetViewPanoramaQuery}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewUsage", Implementation: (*poXJtEkr.GetStreetViewUsage)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/Query.GetStreetViewUsage", Implementations: []interface{}{poXJtEkr.NewGetStreetViewUsageQuery}})
 	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewImageHandler", Implementation: (*mKaXayJi.GetStreetViewImageHandler)(nil)})
 	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewImageHandler", Implementations: []interface{}{mKaXayJi.NewGetStreetViewImageHandler}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Application/QueryHandler.StreetViewImageResult", Implementation: mKaXayJi.StreetViewImageResult{}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewImagesHandler", Implementation: (*mKaXayJi.GetStreetViewImagesHandler)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewImagesHandler", Implementations: []interface{}{mKaXayJi.NewGetStreetViewImagesHandler}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewMetadataHandler", Implementation: (*mKaXayJi.GetStreetViewMetadataHandler)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewMetadataHandler", Implementations: []interface{}{mKaXayJi.NewGetStreetViewMetadataHandler}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewPanoImageHandler", Implementation: (*mKaXayJi.GetStreetViewPanoImageHandler)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewPanoImageHandler", Implementations: []interface{}{mKaXayJi.NewGetStreetViewPanoImageHandler}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewPanoramaHandler", Implementation: (*mKaXayJi.GetStreetViewPanoramaHandler)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewPanoramaHandler", Implementations: []interface{}{mKaXayJi.NewGetStreetViewPanoramaHandler}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/QueryHandler.InFlightImageFetches", Implementation: (*mKaXayJi.InFlightImageFetches)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/QueryHandler.InFlightImageFetches", Implementations: []interface{}{mKaXayJi.NewInFlightImageFetches}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewUsageHandler", Implementation: (*mKaXayJi.GetStreetViewUsageHandler)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Application/QueryHandler.GetStreetViewUsageHandler", Implementations: []interface{}{mKaXayJi.NewGetStreetViewUsageHandler}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Domain.ImageUuid", Implementation: GyZJpPBm.ImageUuid{}})
 	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Domain.StreetViewImages", Implementation: (*GyZJpPBm.StreetViewImages)(nil)})
 	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Domain.StreetViewImage", Implementation: (*GyZJpPBm.StreetViewImage)(nil)})
-	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Domain.StreetViewImage", Implementations: []interface{}{GyZJpPBm.NewStreetViewImage}})
-	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Domain.ImageUuid", Implementations: []interface{}{GyZJpPBm.NewImageUuid}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Domain.ImageParameters", Implementation: GyZJpPBm.ImageParameters{}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Domain.ImageParameters", Implementations: []interface{}{GyZJpPBm.NewImageParameters}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Domain.ImageUuid", Implementations: []interface{}{GyZJpPBm.NewImageUuid, GyZJpPBm.NewPanoImageUuid, GyZJpPBm.NewPanoramaImageUuid}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Domain.StreetViewImage", Implementations: []interface{}{GyZJpPBm.NewStreetViewPanorama, GyZJpPBm.NewStreetViewImage, GyZJpPBm.NewStreetViewImageFromUuid, GyZJpPBm.NewStoredStreetViewImage}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.RetrierFactory", Implementation: olJUMOFZ.RetrierFactory{}})
 	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiClient", Implementation: (*olJUMOFZ.StreetViewApiClient)(nil)})
 	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiClient", Implementations: []interface{}{olJUMOFZ.NewStreetViewApiClient}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewMetadata", Implementation: olJUMOFZ.StreetViewMetadata{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.MetadataLocation", Implementation: olJUMOFZ.MetadataLocation{}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiStatus", Implementation: (*olJUMOFZ.StreetViewApiStatus)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiStatus", Implementations: []interface{}{olJUMOFZ.NewStreetViewApiStatus}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiCircuitBreaker", Implementation: (*olJUMOFZ.StreetViewApiCircuitBreaker)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiCircuitBreaker", Implementations: []interface{}{olJUMOFZ.NewStreetViewApiCircuitBreaker}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiRateLimiter", Implementation: (*olJUMOFZ.StreetViewApiRateLimiter)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiRateLimiter", Implementations: []interface{}{olJUMOFZ.NewStreetViewApiRateLimiter}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiUsage", Implementation: olJUMOFZ.StreetViewApiUsage{}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiBudget", Implementation: (*olJUMOFZ.StreetViewApiBudget)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiBudget", Implementations: []interface{}{olJUMOFZ.NewStreetViewApiBudget}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.RetryError", Implementation: olJUMOFZ.RetryError{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiImage", Implementation: olJUMOFZ.StreetViewApiImage{}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.Retrier", Implementation: (*olJUMOFZ.Retrier)(nil)})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.HttpClient", Implementation: (*olJUMOFZ.HttpClient)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.error", Implementations: []interface{}{olJUMOFZ.NewRequestContextError}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.HttpClient", Implementations: []interface{}{olJUMOFZ.NewHttpClient}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiKey", Implementation: olJUMOFZ.StreetViewApiKey{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiKeyUsage", Implementation: olJUMOFZ.StreetViewApiKeyUsage{}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiKeys", Implementation: (*olJUMOFZ.StreetViewApiKeys)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/ApiClient.StreetViewApiKeys", Implementations: []interface{}{olJUMOFZ.NewStreetViewApiKeys}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Cache.RedisStreetViewImages", Implementation: DpzQhmiZ.RedisStreetViewImages{}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Cache.RedisClientFactory", Implementation: DpzQhmiZ.RedisClientFactory{}})
 	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Cache.RedisClientFactory", Implementations: []interface{}{DpzQhmiZ.NewRedisClientFactory}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Cache.MemoryCacheStats", Implementation: DpzQhmiZ.MemoryCacheStats{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Cache.MemoryStreetViewImages", Implementation: DpzQhmiZ.MemoryStreetViewImages{}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Cache.MemoryStreetViewImages", Implementations: []interface{}{DpzQhmiZ.NewMemoryStreetViewImages}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Cache.FilesystemStreetViewImages", Implementation: DpzQhmiZ.FilesystemStreetViewImages{}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Cache.FilesystemStreetViewImages", Implementations: []interface{}{DpzQhmiZ.NewFilesystemStreetViewImages}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Cache.TierStats", Implementation: DpzQhmiZ.TierStats{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Cache.TieredStreetViewImages", Implementation: DpzQhmiZ.TieredStreetViewImages{}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Cache.TieredStreetViewImages", Implementations: []interface{}{DpzQhmiZ.NewTieredStreetViewImages}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/Health.HealthService", Implementation: (*tnZlTvBi.HealthService)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Health.HealthService", Implementations: []interface{}{tnZlTvBi.NewHealthService}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Logger.FileLogger", Implementation: RKxnsxot.FileLogger{}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Logger.ElasticSearchLogger", Implementation: RKxnsxot.ElasticSearchLogger{}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Infrastructure/Logger.LoggingStrategy", Implementation: RKxnsxot.LoggingStrategy{}})
@@ -45,8 +102,19 @@ func GetRegistry() (registry TypeRegistry.Registry) {
 	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/Server.GrpcServer", Implementation: (*gbLwVnqJ.GrpcServer)(nil)})
 	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Server.GrpcErrorMapper", Implementations: []interface{}{gbLwVnqJ.NewGrpcErrorMapper}})
 	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Server.GrpcServer", Implementations: []interface{}{gbLwVnqJ.New}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/Server.HttpServer", Implementation: (*gbLwVnqJ.HttpServer)(nil)})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Infrastructure/Server.HttpErrorMapper", Implementation: (*gbLwVnqJ.HttpErrorMapper)(nil)})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Server.HttpServer", Implementations: []interface{}{gbLwVnqJ.NewHttpServer}})
+	registry.RegistryFactories = append(registry.RegistryFactories, TypeRegistry.RegistryFactory{Name: "app/src/StreetViewImage/Infrastructure/Server.HttpErrorMapper", Implementations: []interface{}{gbLwVnqJ.NewHttpErrorMapper}})
 	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Presentation/Controller.GetStreetViewImageController", Implementation: PefLEOee.GetStreetViewImageController{}})
 	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Presentation/Controller.GrpcErrorMapper", Implementation: (*PefLEOee.GrpcErrorMapper)(nil)})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Presentation/Controller.GetStreetViewImagesController", Implementation: PefLEOee.GetStreetViewImagesController{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Presentation/Controller.GetStreetViewMetadataController", Implementation: PefLEOee.GetStreetViewMetadataController{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Presentation/Controller.GetStreetViewPanoImageController", Implementation: PefLEOee.GetStreetViewPanoImageController{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Presentation/Controller.GetStreetViewPanoramaController", Implementation: PefLEOee.GetStreetViewPanoramaController{}})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Presentation/Controller.GetStreetViewImageHttpController", Implementation: PefLEOee.GetStreetViewImageHttpController{}})
+	registry.RegistryInterfaces = append(registry.RegistryInterfaces, TypeRegistry.RegistryInterface{Name: "app/src/StreetViewImage/Presentation/Controller.HttpErrorMapper", Implementation: (*PefLEOee.HttpErrorMapper)(nil)})
+	registry.RegistryStructs = append(registry.RegistryStructs, TypeRegistry.RegistryStruct{Name: "app/src/StreetViewImage/Presentation/Controller.GetStreetViewUsageController", Implementation: PefLEOee.GetStreetViewUsageController{}})
 
 	return
 }
@@ -1,20 +1,62 @@
 package Query
 
-/* GetStreetViewImage represents a query used for retrieving an image from Google StreetView. */
+/*
+GetStreetViewImage represents a query used for retrieving an image from Google StreetView.
+
+The camera parameters and size are optional, a nil value means the parameter was not provided.
+*/
 type GetStreetViewImage interface {
 	GetLatitude() float64
 	GetLongitude() float64
+	GetHeading() *float64
+	GetPitch() *float64
+	GetFov() *int
+	GetRadius() *int
+	GetSource() string
+	GetWidth() *int
+	GetHeight() *int
+	GetScale() *int
 }
 
 /* getStreetViewImage represents a query used for retrieving an image from Google StreetView. */
 type getStreetViewImage struct {
 	latitude  float64
 	longitude float64
+	heading   *float64
+	pitch     *float64
+	fov       *int
+	radius    *int
+	source    string
+	width     *int
+	height    *int
+	scale     *int
 }
 
 /* NewGetStreetViewImageQuery returns a new GetStreetViewImage. */
-func NewGetStreetViewImageQuery(latitude float64, longitude float64) GetStreetViewImage {
-	return &getStreetViewImage{latitude: latitude, longitude: longitude}
+func NewGetStreetViewImageQuery(
+	latitude float64,
+	longitude float64,
+	heading *float64,
+	pitch *float64,
+	fov *int,
+	radius *int,
+	source string,
+	width *int,
+	height *int,
+	scale *int,
+) GetStreetViewImage {
+	return &getStreetViewImage{
+		latitude:  latitude,
+		longitude: longitude,
+		heading:   heading,
+		pitch:     pitch,
+		fov:       fov,
+		radius:    radius,
+		source:    source,
+		width:     width,
+		height:    height,
+		scale:     scale,
+	}
 }
 
 /* GetLatitude retrieves the Latitude from the GetStreetViewImage query object. */
@@ -26,3 +68,43 @@ func (q *getStreetViewImage) GetLatitude() float64 {
 func (q *getStreetViewImage) GetLongitude() float64 {
 	return q.longitude
 }
+
+/* GetHeading retrieves the optional Heading from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetHeading() *float64 {
+	return q.heading
+}
+
+/* GetPitch retrieves the optional Pitch from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetPitch() *float64 {
+	return q.pitch
+}
+
+/* GetFov retrieves the optional Fov from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetFov() *int {
+	return q.fov
+}
+
+/* GetRadius retrieves the optional Radius from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetRadius() *int {
+	return q.radius
+}
+
+/* GetSource retrieves the Source from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetSource() string {
+	return q.source
+}
+
+/* GetWidth retrieves the optional Width from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetWidth() *int {
+	return q.width
+}
+
+/* GetHeight retrieves the optional Height from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetHeight() *int {
+	return q.height
+}
+
+/* GetScale retrieves the optional Scale from the GetStreetViewImage query object. */
+func (q *getStreetViewImage) GetScale() *int {
+	return q.scale
+}
@@ -0,0 +1,22 @@
+package Query
+
+/* GetStreetViewImages represents a query used for retrieving a batch of images from Google StreetView at once. */
+type GetStreetViewImages interface {
+	/* GetImageQueries retrieves a query per image, in the order the results should be returned in. */
+	GetImageQueries() []GetStreetViewImage
+}
+
+/* getStreetViewImages represents a query used for retrieving a batch of images from Google StreetView at once. */
+type getStreetViewImages struct {
+	imageQueries []GetStreetViewImage
+}
+
+/* NewGetStreetViewImagesQuery returns a new GetStreetViewImages. */
+func NewGetStreetViewImagesQuery(imageQueries []GetStreetViewImage) GetStreetViewImages {
+	return &getStreetViewImages{imageQueries: imageQueries}
+}
+
+/* GetImageQueries retrieves the query for each image from the GetStreetViewImages query object. */
+func (q *getStreetViewImages) GetImageQueries() []GetStreetViewImage {
+	return q.imageQueries
+}
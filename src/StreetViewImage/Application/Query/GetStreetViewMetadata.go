@@ -0,0 +1,48 @@
+package Query
+
+/*
+GetStreetViewMetadata represents a query used for retrieving the metadata of an image from Google StreetView.
+
+The radius and source are optional, a nil radius means the parameter was not provided.
+*/
+type GetStreetViewMetadata interface {
+	GetLatitude() float64
+	GetLongitude() float64
+	GetRadius() *int
+	GetSource() string
+}
+
+/* getStreetViewMetadata represents a query used for retrieving the metadata of an image from Google StreetView. */
+type getStreetViewMetadata struct {
+	latitude  float64
+	longitude float64
+	radius    *int
+	source    string
+}
+
+/* NewGetStreetViewMetadataQuery returns a new GetStreetViewMetadata. */
+func NewGetStreetViewMetadataQuery(
+	latitude float64, longitude float64, radius *int, source string,
+) GetStreetViewMetadata {
+	return &getStreetViewMetadata{latitude: latitude, longitude: longitude, radius: radius, source: source}
+}
+
+/* GetLatitude retrieves the Latitude from the GetStreetViewMetadata query object. */
+func (q *getStreetViewMetadata) GetLatitude() float64 {
+	return q.latitude
+}
+
+/* GetLongitude retrieves the Longitude from the GetStreetViewMetadata query object. */
+func (q *getStreetViewMetadata) GetLongitude() float64 {
+	return q.longitude
+}
+
+/* GetRadius retrieves the optional Radius from the GetStreetViewMetadata query object. */
+func (q *getStreetViewMetadata) GetRadius() *int {
+	return q.radius
+}
+
+/* GetSource retrieves the Source from the GetStreetViewMetadata query object. */
+func (q *getStreetViewMetadata) GetSource() string {
+	return q.source
+}
@@ -0,0 +1,71 @@
+package Query
+
+/*
+GetStreetViewPanoImage represents a query used for retrieving an image of a specific panorama from Google StreetView.
+
+The camera parameters and size are optional, a nil value means the parameter was not provided.
+*/
+type GetStreetViewPanoImage interface {
+	GetPanoId() string
+	GetHeading() *float64
+	GetPitch() *float64
+	GetFov() *int
+	GetWidth() *int
+	GetHeight() *int
+	GetScale() *int
+}
+
+/* getStreetViewPanoImage represents a query used for retrieving an image of a specific panorama. */
+type getStreetViewPanoImage struct {
+	panoId  string
+	heading *float64
+	pitch   *float64
+	fov     *int
+	width   *int
+	height  *int
+	scale   *int
+}
+
+/* NewGetStreetViewPanoImageQuery returns a new GetStreetViewPanoImage. */
+func NewGetStreetViewPanoImageQuery(
+	panoId string, heading *float64, pitch *float64, fov *int, width *int, height *int, scale *int,
+) GetStreetViewPanoImage {
+	return &getStreetViewPanoImage{
+		panoId: panoId, heading: heading, pitch: pitch, fov: fov, width: width, height: height, scale: scale,
+	}
+}
+
+/* GetPanoId retrieves the PanoId from the GetStreetViewPanoImage query object. */
+func (q *getStreetViewPanoImage) GetPanoId() string {
+	return q.panoId
+}
+
+/* GetHeading retrieves the optional Heading from the GetStreetViewPanoImage query object. */
+func (q *getStreetViewPanoImage) GetHeading() *float64 {
+	return q.heading
+}
+
+/* GetPitch retrieves the optional Pitch from the GetStreetViewPanoImage query object. */
+func (q *getStreetViewPanoImage) GetPitch() *float64 {
+	return q.pitch
+}
+
+/* GetFov retrieves the optional Fov from the GetStreetViewPanoImage query object. */
+func (q *getStreetViewPanoImage) GetFov() *int {
+	return q.fov
+}
+
+/* GetWidth retrieves the optional Width from the GetStreetViewPanoImage query object. */
+func (q *getStreetViewPanoImage) GetWidth() *int {
+	return q.width
+}
+
+/* GetHeight retrieves the optional Height from the GetStreetViewPanoImage query object. */
+func (q *getStreetViewPanoImage) GetHeight() *int {
+	return q.height
+}
+
+/* GetScale retrieves the optional Scale from the GetStreetViewPanoImage query object. */
+func (q *getStreetViewPanoImage) GetScale() *int {
+	return q.scale
+}
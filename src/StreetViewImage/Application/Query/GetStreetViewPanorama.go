@@ -0,0 +1,94 @@
+package Query
+
+/*
+GetStreetViewPanorama represents a query used for retrieving a 360 degree panorama of a location, stitched from images
+taken at evenly spaced headings.
+
+A zero number of slices means the default should be used; the size and camera parameters are optional, a nil value
+means the parameter was not provided.
+*/
+type GetStreetViewPanorama interface {
+	GetLatitude() float64
+	GetLongitude() float64
+	GetSlices() int
+	GetWidth() *int
+	GetHeight() *int
+	GetPitch() *float64
+	GetRadius() *int
+	GetSource() string
+}
+
+/* getStreetViewPanorama represents a query used for retrieving a 360 degree panorama of a location. */
+type getStreetViewPanorama struct {
+	latitude  float64
+	longitude float64
+	slices    int
+	width     *int
+	height    *int
+	pitch     *float64
+	radius    *int
+	source    string
+}
+
+/* NewGetStreetViewPanoramaQuery returns a new GetStreetViewPanorama. */
+func NewGetStreetViewPanoramaQuery(
+	latitude float64,
+	longitude float64,
+	slices int,
+	width *int,
+	height *int,
+	pitch *float64,
+	radius *int,
+	source string,
+) GetStreetViewPanorama {
+	return &getStreetViewPanorama{
+		latitude:  latitude,
+		longitude: longitude,
+		slices:    slices,
+		width:     width,
+		height:    height,
+		pitch:     pitch,
+		radius:    radius,
+		source:    source,
+	}
+}
+
+/* GetLatitude retrieves the Latitude from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetLatitude() float64 {
+	return q.latitude
+}
+
+/* GetLongitude retrieves the Longitude from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetLongitude() float64 {
+	return q.longitude
+}
+
+/* GetSlices retrieves the number of Slices from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetSlices() int {
+	return q.slices
+}
+
+/* GetWidth retrieves the optional Width of each slice from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetWidth() *int {
+	return q.width
+}
+
+/* GetHeight retrieves the optional Height of each slice from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetHeight() *int {
+	return q.height
+}
+
+/* GetPitch retrieves the optional Pitch from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetPitch() *float64 {
+	return q.pitch
+}
+
+/* GetRadius retrieves the optional Radius from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetRadius() *int {
+	return q.radius
+}
+
+/* GetSource retrieves the Source from the GetStreetViewPanorama query object. */
+func (q *getStreetViewPanorama) GetSource() string {
+	return q.source
+}
@@ -0,0 +1,12 @@
+package Query
+
+/* GetStreetViewUsage represents a query used for retrieving the billable requests made to Google StreetView. */
+type GetStreetViewUsage interface{}
+
+/* getStreetViewUsage represents a query used for retrieving the billable requests made to Google StreetView. */
+type getStreetViewUsage struct{}
+
+/* NewGetStreetViewUsageQuery returns a new GetStreetViewUsage. */
+func NewGetStreetViewUsageQuery() GetStreetViewUsage {
+	return &getStreetViewUsage{}
+}
@@ -1,70 +1,74 @@
 package QueryHandler
 
 import (
-	"app/src/StreetViewImage/Application/Error"
+	"app/config"
 	"app/src/StreetViewImage/Application/Query"
 	"app/src/StreetViewImage/Domain"
 	"app/src/StreetViewImage/Infrastructure/ApiClient"
 	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
 	"fmt"
 )
 
 /* GetStreetViewImageHandler handles a query to retrieve an image from Google StreetView. */
 type GetStreetViewImageHandler interface {
-	/* Handle takes in a Query and returns an array of bytes containing an image / an error. */
-	Handle(query Query.GetStreetViewImage) ([]byte, error)
+	/* Handle takes in a Query and returns the image / an error, giving up once the context of the request is done. */
+	Handle(ctx context.Context, query Query.GetStreetViewImage) (Domain.StreetViewImage, error)
 }
 
 /* getStreetViewImage handles a query to retrieve an image from Google StreetView. */
 type getStreetViewImageHandler struct {
 	repository Domain.StreetViewImages
-	apiClient  ApiClient.StreetViewApiClient
+	fetcher    *streetViewImageFetcher
+	sizer      *imageSizer
 	logger     Logger.LoggingStrategy
+
+	/* provider is the imagery provider the api client retrieves images from, part of every image's uuid. */
+	provider string
 }
 
 /* NewGetStreetViewImageHandler returns a new GetStreetViewImageHandler. */
 func NewGetStreetViewImageHandler(
-	repository Domain.StreetViewImages, apiClient ApiClient.StreetViewApiClient, logger Logger.LoggingStrategy,
+	repository Domain.StreetViewImages,
+	apiClient ApiClient.StreetViewApiClient,
+	logger Logger.LoggingStrategy,
+	config config.StreetViewApiConfiguration,
+	inFlightFetches InFlightImageFetches,
 ) GetStreetViewImageHandler {
-	return &getStreetViewImageHandler{repository: repository, apiClient: apiClient, logger: logger}
+	return &getStreetViewImageHandler{
+		repository: repository,
+		fetcher:    newStreetViewImageFetcher(repository, apiClient, inFlightFetches),
+		sizer:      newImageSizer(config),
+		logger:     logger,
+		provider:   apiClient.GetProvider(),
+	}
 }
 
-/* Handle takes in a Query and returns an array of bytes containing an image / an error. */
-func (h *getStreetViewImageHandler) Handle(query Query.GetStreetViewImage) ([]byte, error) {
+/* Handle takes in a Query and returns the image / an error. */
+func (h *getStreetViewImageHandler) Handle(
+	ctx context.Context, query Query.GetStreetViewImage,
+) (Domain.StreetViewImage, error) {
 	lat, lon := query.GetLatitude(), query.GetLongitude()
-
-	img := h.repository.Find(lat, lon)
-
-	if img != nil {
-		h.logger.Debug(fmt.Sprintf("Cache already contains image for lat: '%f', lon: '%f', returning...", lat, lon))
-
-		return img.GetBytes(), nil
-	}
-
-	responseBytes, err := h.apiClient.Request(lat, lon)
+	parameters, err := h.sizer.Apply(
+		Domain.NewImageParameters(
+			query.GetHeading(), query.GetPitch(), query.GetFov(), query.GetRadius(), query.GetSource(),
+		).WithProvider(h.provider),
+		query.GetWidth(),
+		query.GetHeight(),
+		query.GetScale(),
+	)
 
 	if err != nil {
-		/* Assertion here as we only know an image doesn't exist in streetview (user's fault) when in the domain. */
-		if _, isUserError := err.(Error.UserError); isUserError {
-			return nil, err
-		}
-
-		return nil, Error.NewApplicationError(
-			fmt.Sprintf("Unable to perform request to streetview API, error: %s", err.Error()),
-		)
+		return nil, err
 	}
 
-	image, err := Domain.NewStreetViewImage(lat, lon, responseBytes)
-
-	if err != nil {
-		return nil, Error.NewApplicationError(
-			fmt.Sprintf("Looks like the StreetView api image was not considered a valid image. Error: %s", err.Error()),
-		)
-	}
+	img := h.repository.Find(ctx, lat, lon, parameters)
 
-	fmt.Println(h.repository)
+	if img != nil {
+		h.logger.Debug(fmt.Sprintf("Cache already contains image for lat: '%f', lon: '%f', returning...", lat, lon))
 
-	image.Save(h.repository)
+		return img, nil
+	}
 
-	return image.GetBytes(), nil
+	return h.fetcher.Fetch(ctx, Domain.NewImageUuid(lat, lon, parameters))
 }
@@ -0,0 +1,188 @@
+package QueryHandler
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	/* maxBatchSize is the maximum number of images that can be requested in a single batch. */
+	maxBatchSize = 100
+
+	/* Error constants. */
+	BatchTooLargeCode = "BatchTooLarge"
+	BatchTooLargeErr  = "too many locations provided: a maximum of 100 images can be requested at once"
+)
+
+/* StreetViewImageResult is the outcome for a single image in a batch: either the image bytes or an error. */
+type StreetViewImageResult struct {
+	Image []byte
+	Err   error
+	/* Provider is the imagery provider the image was retrieved from, empty with an error. */
+	Provider string
+}
+
+/* GetStreetViewImagesHandler handles a query to retrieve a batch of images from Google StreetView. */
+type GetStreetViewImagesHandler interface {
+	/*
+		Handle takes in a Query and returns a result per image in the same order as the query. An error is only returned
+		when the batch as a whole could not be handled; a failure for a single image is contained in it's result.
+	*/
+	Handle(ctx context.Context, query Query.GetStreetViewImages) ([]StreetViewImageResult, error)
+}
+
+/* getStreetViewImagesHandler handles a query to retrieve a batch of images from Google StreetView. */
+type getStreetViewImagesHandler struct {
+	repository Domain.StreetViewImages
+	fetcher    *streetViewImageFetcher
+	sizer      *imageSizer
+	logger     Logger.LoggingStrategy
+
+	/* maxConcurrentRequests bounds the number of cache misses fetched from the api at the same time. */
+	maxConcurrentRequests int
+
+	/* provider is the imagery provider the api client retrieves images from, part of every image's uuid. */
+	provider string
+}
+
+/* NewGetStreetViewImagesHandler returns a new GetStreetViewImagesHandler. */
+func NewGetStreetViewImagesHandler(
+	repository Domain.StreetViewImages,
+	apiClient ApiClient.StreetViewApiClient,
+	logger Logger.LoggingStrategy,
+	config config.StreetViewApiConfiguration,
+	inFlightFetches InFlightImageFetches,
+) GetStreetViewImagesHandler {
+	maxConcurrentRequests := config.GetMaxConcurrentRequests()
+
+	if maxConcurrentRequests < 1 {
+		maxConcurrentRequests = 1
+	}
+
+	return &getStreetViewImagesHandler{
+		repository:            repository,
+		fetcher:               newStreetViewImageFetcher(repository, apiClient, inFlightFetches),
+		sizer:                 newImageSizer(config),
+		logger:                logger,
+		maxConcurrentRequests: maxConcurrentRequests,
+		provider:              apiClient.GetProvider(),
+	}
+}
+
+/*
+Handle takes in a Query and returns a result per image in the same order as the query.
+
+All images are looked up in the repository at once, then any misses are fetched from the api concurrently. The batch
+as a whole fails once the context of the request is done, as the rest of it's results would only be that anyway.
+*/
+func (h *getStreetViewImagesHandler) Handle(
+	ctx context.Context, query Query.GetStreetViewImages,
+) ([]StreetViewImageResult, error) {
+	imageQueries := query.GetImageQueries()
+
+	if len(imageQueries) > maxBatchSize {
+		return nil, Error.UserError{Code: BatchTooLargeCode, Err: BatchTooLargeErr}
+	}
+
+	results := make([]StreetViewImageResult, len(imageQueries))
+
+	/* Only valid queries are looked up, resultIndexes holds the index in the results for each of their uuids. */
+	var uuids []*Domain.ImageUuid
+	var resultIndexes []int
+
+	for index, imageQuery := range imageQueries {
+		parameters, err := h.createParameters(imageQuery)
+
+		if err != nil {
+			results[index] = StreetViewImageResult{Err: err}
+
+			continue
+		}
+
+		uuids = append(uuids, Domain.NewImageUuid(imageQuery.GetLatitude(), imageQuery.GetLongitude(), parameters))
+		resultIndexes = append(resultIndexes, index)
+	}
+
+	var misses []int
+
+	for uuidIndex, image := range h.repository.FindMany(ctx, uuids) {
+		if image == nil {
+			misses = append(misses, uuidIndex)
+
+			continue
+		}
+
+		results[resultIndexes[uuidIndex]] = StreetViewImageResult{Image: image.GetBytes(), Provider: image.GetProvider()}
+	}
+
+	h.logger.Debug(
+		fmt.Sprintf("Cache contains %d of %d images in batch, fetching the rest...", len(uuids)-len(misses), len(uuids)),
+	)
+
+	h.fetchMisses(ctx, uuids, misses, resultIndexes, results)
+
+	if err := ApiClient.NewRequestContextError(ctx); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+/* fetchMisses fetches the images for the uuid indexes, at most maxConcurrentRequests at once, storing the results. */
+func (h *getStreetViewImagesHandler) fetchMisses(
+	ctx context.Context, uuids []*Domain.ImageUuid, misses []int, resultIndexes []int, results []StreetViewImageResult,
+) {
+	semaphore := make(chan struct{}, h.maxConcurrentRequests)
+
+	var waitGroup sync.WaitGroup
+
+	for _, uuidIndex := range misses {
+		waitGroup.Add(1)
+
+		semaphore <- struct{}{}
+
+		go func(uuidIndex int) {
+			defer func() {
+				<-semaphore
+
+				waitGroup.Done()
+			}()
+
+			index := resultIndexes[uuidIndex]
+
+			image, err := h.fetcher.Fetch(ctx, uuids[uuidIndex])
+
+			if err != nil {
+				/* Each index is only ever written to by one goroutine, so no locking is required here. */
+				results[index] = StreetViewImageResult{Err: err}
+
+				return
+			}
+
+			results[index] = StreetViewImageResult{Image: image.GetBytes(), Provider: image.GetProvider()}
+		}(uuidIndex)
+	}
+
+	waitGroup.Wait()
+}
+
+/* createParameters creates the domain image parameters from a single image query, or an error if they are invalid. */
+func (h *getStreetViewImagesHandler) createParameters(
+	query Query.GetStreetViewImage,
+) (*Domain.ImageParameters, error) {
+	return h.sizer.Apply(
+		Domain.NewImageParameters(
+			query.GetHeading(), query.GetPitch(), query.GetFov(), query.GetRadius(), query.GetSource(),
+		).WithProvider(h.provider),
+		query.GetWidth(),
+		query.GetHeight(),
+		query.GetScale(),
+	)
+}
@@ -0,0 +1,59 @@
+package QueryHandler
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"fmt"
+)
+
+/* GetStreetViewMetadataHandler handles a query to retrieve the metadata of an image from Google StreetView. */
+type GetStreetViewMetadataHandler interface {
+	/*
+		Handle takes in a Query and returns the metadata / an error. A location without coverage is not an error, the
+		status of the returned metadata says so instead.
+	*/
+	Handle(ctx context.Context, query Query.GetStreetViewMetadata) (*ApiClient.StreetViewMetadata, error)
+}
+
+/* getStreetViewMetadataHandler handles a query to retrieve the metadata of an image from Google StreetView. */
+type getStreetViewMetadataHandler struct {
+	apiClient ApiClient.StreetViewApiClient
+	logger    Logger.LoggingStrategy
+}
+
+/* NewGetStreetViewMetadataHandler returns a new GetStreetViewMetadataHandler. */
+func NewGetStreetViewMetadataHandler(
+	apiClient ApiClient.StreetViewApiClient, logger Logger.LoggingStrategy,
+) GetStreetViewMetadataHandler {
+	return &getStreetViewMetadataHandler{apiClient: apiClient, logger: logger}
+}
+
+/* Handle takes in a Query and returns the metadata / an error. */
+func (h *getStreetViewMetadataHandler) Handle(
+	ctx context.Context, query Query.GetStreetViewMetadata,
+) (*ApiClient.StreetViewMetadata, error) {
+	lat, lon := query.GetLatitude(), query.GetLongitude()
+
+	/* Only the radius and source affect which panorama is found, the camera parameters are for the image itself. */
+	parameters := Domain.NewImageParameters(nil, nil, nil, query.GetRadius(), query.GetSource())
+
+	metadata, err := h.apiClient.RequestMetadata(ctx, lat, lon, parameters)
+
+	if err != nil {
+		if _, isUserError := err.(Error.UserError); isUserError {
+			return nil, err
+		}
+
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Unable to perform metadata request to streetview API, error: %s", err.Error()),
+		)
+	}
+
+	h.logger.Debug(fmt.Sprintf("Metadata for lat: '%f', lon: '%f' has status: '%s'", lat, lon, metadata.Status))
+
+	return metadata, nil
+}
@@ -0,0 +1,97 @@
+package QueryHandler
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/* Error constants. */
+const (
+	EmptyPanoIdCode = "EmptyPanoId"
+	EmptyPanoIdErr  = "no pano id provided: a pano id is required to request a specific panorama"
+)
+
+/*
+panoIdRegex matches the pano ids of Google (and the image ids of Mapillary), which are url-safe base64 or digits. Any
+other character could pass for part of the cache key the pano id is placed in, such as the ':' between parameters.
+*/
+var panoIdRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+/* GetStreetViewPanoImageHandler handles a query to retrieve an image of a specific panorama from Google StreetView. */
+type GetStreetViewPanoImageHandler interface {
+	/* Handle takes in a Query and returns the image / an error. */
+	Handle(ctx context.Context, query Query.GetStreetViewPanoImage) (Domain.StreetViewImage, error)
+}
+
+/* getStreetViewPanoImageHandler handles a query to retrieve an image of a specific panorama from Google StreetView. */
+type getStreetViewPanoImageHandler struct {
+	repository Domain.StreetViewImages
+	fetcher    *streetViewImageFetcher
+	sizer      *imageSizer
+	logger     Logger.LoggingStrategy
+
+	/* provider is the imagery provider the api client retrieves images from, part of every image's uuid. */
+	provider string
+}
+
+/* NewGetStreetViewPanoImageHandler returns a new GetStreetViewPanoImageHandler. */
+func NewGetStreetViewPanoImageHandler(
+	repository Domain.StreetViewImages,
+	apiClient ApiClient.StreetViewApiClient,
+	logger Logger.LoggingStrategy,
+	config config.StreetViewApiConfiguration,
+	inFlightFetches InFlightImageFetches,
+) GetStreetViewPanoImageHandler {
+	return &getStreetViewPanoImageHandler{
+		repository: repository,
+		fetcher:    newStreetViewImageFetcher(repository, apiClient, inFlightFetches),
+		sizer:      newImageSizer(config),
+		logger:     logger,
+		provider:   apiClient.GetProvider(),
+	}
+}
+
+/* Handle takes in a Query and returns the image / an error. */
+func (h *getStreetViewPanoImageHandler) Handle(
+	ctx context.Context, query Query.GetStreetViewPanoImage,
+) (Domain.StreetViewImage, error) {
+	panoId := strings.TrimSpace(query.GetPanoId())
+
+	if panoId == "" {
+		return nil, Error.UserError{Code: EmptyPanoIdCode, Err: EmptyPanoIdErr}
+	}
+
+	if !panoIdRegex.MatchString(panoId) {
+		return nil, Error.UserError{Code: ApiClient.InvalidPanoIdCode, Err: ApiClient.InvalidPanoIdErr}
+	}
+
+	/* Radius and source only affect which panorama is found for a location, so they have no meaning here. */
+	parameters, err := h.sizer.Apply(
+		Domain.NewImageParameters(query.GetHeading(), query.GetPitch(), query.GetFov(), nil, "").WithProvider(h.provider),
+		query.GetWidth(),
+		query.GetHeight(),
+		query.GetScale(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	img := h.repository.FindByPanoId(ctx, panoId, parameters)
+
+	if img != nil {
+		h.logger.Debug(fmt.Sprintf("Cache already contains image for pano: '%s', returning...", panoId))
+
+		return img, nil
+	}
+
+	return h.fetcher.Fetch(ctx, Domain.NewPanoImageUuid(panoId, parameters))
+}
@@ -0,0 +1,83 @@
+package QueryHandler
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Cache"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"app/test/FakeStreetView"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/j7mbo/goenvconfig"
+)
+
+/* newTestPanoImageHandler returns a handler retrieving images from the fake at the endpoint, held in memory. */
+func newTestPanoImageHandler(t *testing.T, endpoint string) GetStreetViewPanoImageHandler {
+	client := newTestApiClient(t, endpoint, map[string]string{}, testRateLimiter{})
+
+	apiConfig, cacheConfig := config.StreetViewApiConfiguration{}, config.CacheConfiguration{}
+
+	/* The environment is that the api client was configured from. */
+	for _, configuration := range []interface{}{&apiConfig, &cacheConfig} {
+		if err := goenvconfig.NewGoEnvParser().Parse(configuration); err != nil {
+			t.Fatalf("Unable to parse the test configuration, error: '%s'", err.Error())
+		}
+	}
+
+	logger := Logger.LoggingStrategy{}
+
+	return NewGetStreetViewPanoImageHandler(
+		Cache.NewMemoryStreetViewImages(cacheConfig, &logger), client, logger, apiConfig, NewInFlightImageFetches(),
+	)
+}
+
+func TestGetStreetViewPanoImageHandlerRefusesAnInvalidPanoId(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	handler := newTestPanoImageHandler(t, endpoint)
+
+	tests := []struct {
+		name     string
+		panoId   string
+		expected string
+	}{
+		{name: "google's", panoId: "tu510ie_z4ptBZYo2BGEJg"},
+		{name: "mapillary's", panoId: "1234567890"},
+		{name: "empty", panoId: "  ", expected: EmptyPanoIdCode},
+		/* Otherwise the key of the pano "X" at a heading of 90, whatever the heading requested. */
+		{name: "a parameter", panoId: "X:heading=90.000000", expected: ApiClient.InvalidPanoIdCode},
+		{name: "a path", panoId: "../X", expected: ApiClient.InvalidPanoIdCode},
+		{name: "too long", panoId: strings.Repeat("X", 129), expected: ApiClient.InvalidPanoIdCode},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake.Reset()
+
+			image, err := handler.Handle(context.Background(), Query.NewGetStreetViewPanoImageQuery(
+				test.panoId, nil, nil, nil, nil, nil, nil,
+			))
+
+			if test.expected == "" {
+				if err != nil || image == nil {
+					t.Errorf("Expected the image, got error: '%v'", err)
+				}
+
+				return
+			}
+
+			if userErr, isUserErr := err.(Error.UserError); !isUserErr || userErr.Code != test.expected {
+				t.Errorf("Expected a user error with code: '%s', got: '%v'", test.expected, err)
+			}
+
+			if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 0 {
+				t.Errorf("Expected no requests to be made, got: '%d' metadata requests", count)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package QueryHandler
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+const (
+	/* The number of slices a panorama can be stitched from. Fewer than 3 would require a fov over the API max of 120. */
+	defaultPanoramaSlices = 4
+	minPanoramaSlices     = 3
+	maxPanoramaSlices     = 12
+
+	/* Error constants. */
+	InvalidPanoramaSlicesCode = "InvalidPanoramaSlices"
+	InvalidPanoramaSlicesErr  = "invalid number of slices provided: a panorama can be stitched from 3 to 12 slices"
+)
+
+/* GetStreetViewPanoramaHandler handles a query to retrieve a 360 degree panorama stitched from StreetView images. */
+type GetStreetViewPanoramaHandler interface {
+	/* Handle takes in a Query and returns the stitched image / an error. */
+	Handle(ctx context.Context, query Query.GetStreetViewPanorama) (Domain.StreetViewImage, error)
+}
+
+/* getStreetViewPanoramaHandler handles a query to retrieve a 360 degree panorama stitched from StreetView images. */
+type getStreetViewPanoramaHandler struct {
+	repository Domain.StreetViewImages
+	apiClient  ApiClient.StreetViewApiClient
+	sizer      *imageSizer
+	logger     Logger.LoggingStrategy
+
+	/* inFlight coalesces the fetches of the same panorama, so that concurrent requests for it pay for the slices once. */
+	inFlight InFlightImageFetches
+
+	/* maxConcurrentRequests bounds the number of slices fetched from the api at the same time. */
+	maxConcurrentRequests int
+
+	/* provider is the imagery provider the api client retrieves images from, part of every image's uuid. */
+	provider string
+}
+
+/* NewGetStreetViewPanoramaHandler returns a new GetStreetViewPanoramaHandler. */
+func NewGetStreetViewPanoramaHandler(
+	repository Domain.StreetViewImages,
+	apiClient ApiClient.StreetViewApiClient,
+	logger Logger.LoggingStrategy,
+	config config.StreetViewApiConfiguration,
+	inFlightFetches InFlightImageFetches,
+) GetStreetViewPanoramaHandler {
+	maxConcurrentRequests := config.GetMaxConcurrentRequests()
+
+	if maxConcurrentRequests < 1 {
+		maxConcurrentRequests = 1
+	}
+
+	return &getStreetViewPanoramaHandler{
+		repository:            repository,
+		apiClient:             apiClient,
+		sizer:                 newImageSizer(config),
+		logger:                logger,
+		inFlight:              inFlightFetches,
+		maxConcurrentRequests: maxConcurrentRequests,
+		provider:              apiClient.GetProvider(),
+	}
+}
+
+/*
+Handle takes in a Query and returns the stitched image / an error.
+
+Only the stitched panorama is cached, the slices it is made of are not as they are of little use on their own.
+Concurrent requests for the same panorama are coalesced into one fetch, all of them receiving the same panorama / error.
+*/
+func (h *getStreetViewPanoramaHandler) Handle(
+	ctx context.Context, query Query.GetStreetViewPanorama,
+) (Domain.StreetViewImage, error) {
+	slices := query.GetSlices()
+
+	if slices == 0 {
+		slices = defaultPanoramaSlices
+	}
+
+	if slices < minPanoramaSlices || slices > maxPanoramaSlices {
+		return nil, Error.UserError{Code: InvalidPanoramaSlicesCode, Err: InvalidPanoramaSlicesErr}
+	}
+
+	parameters, err := h.createParameters(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lat, lon := query.GetLatitude(), query.GetLongitude()
+
+	img := h.repository.FindPanorama(ctx, lat, lon, slices, parameters)
+
+	if img != nil {
+		h.logger.Debug(fmt.Sprintf("Cache already contains panorama for lat: '%f', lon: '%f', returning...", lat, lon))
+
+		return img, nil
+	}
+
+	panoramaUuid := Domain.NewPanoramaImageUuid(lat, lon, slices, parameters)
+
+	if h.repository.HasNoCoverage(ctx, panoramaUuid) {
+		return nil, newNoCoverageError(panoramaUuid)
+	}
+
+	return h.inFlight.Do(ctx, panoramaUuid, func(ctx context.Context) (Domain.StreetViewImage, error) {
+		return h.fetch(ctx, panoramaUuid, lat, lon, slices, parameters)
+	})
+}
+
+/* fetch fetches the slices of the panorama from the api, stitches them together and saves the panorama. */
+func (h *getStreetViewPanoramaHandler) fetch(
+	ctx context.Context,
+	panoramaUuid *Domain.ImageUuid,
+	latitude float64,
+	longitude float64,
+	slices int,
+	parameters *Domain.ImageParameters,
+) (Domain.StreetViewImage, error) {
+	sliceBytes, provider, err := h.fetchSlices(ctx, latitude, longitude, slices, parameters)
+
+	if err != nil {
+		if userError, isUserError := err.(Error.UserError); isUserError && isNoCoverageError(userError) {
+			h.repository.SaveNoCoverage(ctx, panoramaUuid)
+		}
+
+		return nil, err
+	}
+
+	panorama, err := Domain.NewStreetViewPanorama(panoramaUuid, sliceBytes, provider)
+
+	if err != nil {
+		return nil, Error.NewApplicationError(fmt.Sprintf("Unable to stitch panorama, error: %s", err.Error()))
+	}
+
+	/* The slices have been paid for by now, so the panorama is saved even if every request waiting for it has given up. */
+	panorama.Save(context.WithoutCancel(ctx), h.repository)
+
+	return panorama, nil
+}
+
+/*
+fetchSlices fetches an image per slice from the api, at most maxConcurrentRequests at once, returning them along with
+the imagery provider they were all retrieved from. The headings are evenly spaced starting from north, and the fov is
+just wide enough for the slices to cover the full 360 degrees.
+
+The metadata of the location is requested once up front, rather than before every slice, see: WithCheckedCoverage.
+*/
+func (h *getStreetViewPanoramaHandler) fetchSlices(
+	ctx context.Context, latitude float64, longitude float64, slices int, parameters *Domain.ImageParameters,
+) ([][]byte, string, error) {
+	metadata, err := h.apiClient.RequestMetadata(ctx, latitude, longitude, parameters)
+
+	if err != nil {
+		return nil, "", newSliceRequestError(err)
+	}
+
+	if metadata.Status != ApiClient.MetadataStatusOk {
+		return nil, "", Error.UserError{Code: ApiClient.InvalidLocationCode, Err: ApiClient.InvalidLocationCodeErr}
+	}
+
+	ctx = ApiClient.WithCheckedCoverage(ctx, metadata)
+
+	sliceImages := make([]*ApiClient.StreetViewApiImage, slices)
+	sliceErrors := make([]error, slices)
+
+	headingStep := 360.0 / float64(slices)
+	fov := int(math.Ceil(headingStep))
+
+	semaphore := make(chan struct{}, h.maxConcurrentRequests)
+
+	var waitGroup sync.WaitGroup
+
+	for index := 0; index < slices; index++ {
+		waitGroup.Add(1)
+
+		semaphore <- struct{}{}
+
+		go func(index int) {
+			defer func() {
+				<-semaphore
+
+				waitGroup.Done()
+			}()
+
+			heading := headingStep * float64(index)
+
+			sliceParameters := Domain.NewImageParameters(
+				&heading, parameters.GetPitch(), &fov, parameters.GetRadius(), parameters.GetSource(),
+			)
+
+			if parameters.GetWidth() != nil && parameters.GetHeight() != nil {
+				sliceParameters = sliceParameters.WithSize(*parameters.GetWidth(), *parameters.GetHeight())
+			}
+
+			/* Each index is only ever written to by one goroutine, so no locking is required here. */
+			sliceImages[index], sliceErrors[index] = h.apiClient.Request(ctx, latitude, longitude, sliceParameters)
+		}(index)
+	}
+
+	waitGroup.Wait()
+
+	for _, err := range sliceErrors {
+		if err != nil {
+			return nil, "", newSliceRequestError(err)
+		}
+	}
+
+	sliceBytes := make([][]byte, slices)
+	provider := sliceImages[0].Provider
+
+	for index, sliceImage := range sliceImages {
+		/* A provider failing part way through leaves slices from different providers, which do not stitch together. */
+		if sliceImage.Provider != provider {
+			return nil, "", Error.NewApplicationError(fmt.Sprintf(
+				"Panorama slices were retrieved from different providers: '%s' and '%s'", provider, sliceImage.Provider,
+			))
+		}
+
+		sliceBytes[index] = sliceImage.Bytes
+	}
+
+	return sliceBytes, provider, nil
+}
+
+/* createParameters validates the optional slice size and creates the parameters shared by every slice. */
+func (h *getStreetViewPanoramaHandler) createParameters(
+	query Query.GetStreetViewPanorama,
+) (*Domain.ImageParameters, error) {
+	parameters := Domain.NewImageParameters(
+		nil, query.GetPitch(), nil, query.GetRadius(), query.GetSource(),
+	).WithProvider(h.provider)
+
+	return h.sizer.Apply(parameters, query.GetWidth(), query.GetHeight(), nil)
+}
+
+/* newSliceRequestError returns the error of a failed api request for a panorama's slices. */
+func newSliceRequestError(err error) error {
+	/* Assertion here as we only know an image doesn't exist in streetview (user's fault) when in the domain. */
+	if _, isUserError := err.(Error.UserError); isUserError {
+		return err
+	}
+
+	return Error.NewApplicationError(
+		fmt.Sprintf("Unable to perform request to streetview API for panorama slice, error: %s", err.Error()),
+	)
+}
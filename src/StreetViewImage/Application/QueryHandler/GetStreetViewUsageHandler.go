@@ -0,0 +1,44 @@
+package QueryHandler
+
+import (
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"context"
+)
+
+/*
+GetStreetViewUsageHandler handles a query to retrieve the billable requests made to Google StreetView this month, along
+with the calls made with each api key by this instance.
+*/
+type GetStreetViewUsageHandler interface {
+	/* Handle takes in a Query and returns the usage / an error. */
+	Handle(ctx context.Context, query Query.GetStreetViewUsage) (*ApiClient.StreetViewApiUsage, error)
+}
+
+/* getStreetViewUsageHandler handles a query to retrieve the billable requests made to Google StreetView this month. */
+type getStreetViewUsageHandler struct {
+	budget ApiClient.StreetViewApiBudget
+	keys   ApiClient.StreetViewApiKeys
+}
+
+/* NewGetStreetViewUsageHandler returns a new GetStreetViewUsageHandler. */
+func NewGetStreetViewUsageHandler(
+	budget ApiClient.StreetViewApiBudget, keys ApiClient.StreetViewApiKeys,
+) GetStreetViewUsageHandler {
+	return &getStreetViewUsageHandler{budget: budget, keys: keys}
+}
+
+/* Handle takes in a Query and returns the usage / an error. */
+func (h *getStreetViewUsageHandler) Handle(
+	ctx context.Context, query Query.GetStreetViewUsage,
+) (*ApiClient.StreetViewApiUsage, error) {
+	usage, err := h.budget.GetUsage(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	usage.ApiKeys = h.keys.GetUsage()
+
+	return usage, nil
+}
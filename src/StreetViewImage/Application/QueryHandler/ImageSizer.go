@@ -0,0 +1,62 @@
+package QueryHandler
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+)
+
+/* Error constants. */
+const (
+	InvalidImageSizeCode  = "InvalidImageSize"
+	InvalidImageSizeErr   = "invalid image size provided: width and height must both be provided, from 1 to 640"
+	InvalidImageScaleCode = "InvalidImageScale"
+	InvalidImageScaleErr  = "invalid image scale provided: the scale must be 1, or 2 where permitted"
+)
+
+/*
+imageSizer validates the optional size and scale an image is requested with against the limits of the api, so that an
+out of range value is rejected rather than being silently clamped to something the user did not ask for.
+*/
+type imageSizer struct {
+	/* maxScale is the max scale permitted by the api plan, scale=2 is not available to everyone. */
+	maxScale int
+}
+
+/* newImageSizer returns a new imageSizer. */
+func newImageSizer(config config.StreetViewApiConfiguration) *imageSizer {
+	return &imageSizer{maxScale: config.GetMaxScale()}
+}
+
+/*
+Apply validates the size and scale, returning a copy of the parameters with them set. A nil width, height and scale
+means they were not provided, so the configured defaults are used instead.
+*/
+func (s *imageSizer) Apply(parameters *Domain.ImageParameters, width *int, height *int, scale *int) (
+	*Domain.ImageParameters, error,
+) {
+	if width != nil || height != nil {
+		if width == nil || height == nil || !isWithinRange(*width, ApiClient.MaxWidth) ||
+			!isWithinRange(*height, ApiClient.MaxHeight) {
+			return nil, Error.UserError{Code: InvalidImageSizeCode, Err: InvalidImageSizeErr}
+		}
+
+		parameters = parameters.WithSize(*width, *height)
+	}
+
+	if scale != nil {
+		if !isWithinRange(*scale, s.maxScale) || *scale > 2 {
+			return nil, Error.UserError{Code: InvalidImageScaleCode, Err: InvalidImageScaleErr}
+		}
+
+		parameters = parameters.WithScale(*scale)
+	}
+
+	return parameters, nil
+}
+
+/* isWithinRange returns whether a size or scale is at least 1 and at most max. */
+func isWithinRange(value int, max int) bool {
+	return value > 0 && value <= max
+}
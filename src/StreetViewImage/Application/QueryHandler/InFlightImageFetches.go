@@ -0,0 +1,271 @@
+package QueryHandler
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/*
+InFlightImageFetches coalesces fetches of the same image that happen at the same time, so that when many requests miss
+the cache for one image only a single (billable) call is made to the api and every request waits for it's result.
+*/
+type InFlightImageFetches interface {
+	/*
+		Do calls fetch for the uuid unless a fetch for the same uuid is already in flight, in which case it waits for and
+		returns the image / error of that one instead. Each request stops waiting once it's own context is done, and the
+		fetch itself is cancelled once every request waiting for it has or the latest of their deadlines passes.
+	*/
+	Do(
+		ctx context.Context,
+		imageUuid *Domain.ImageUuid,
+		fetch func(ctx context.Context) (Domain.StreetViewImage, error),
+	) (Domain.StreetViewImage, error)
+}
+
+/* inFlightImageFetch is a single fetch that is in flight, done is closed once it's image / error are available. */
+type inFlightImageFetch struct {
+	done  chan struct{}
+	image Domain.StreetViewImage
+	err   error
+
+	/* waiters is the number of requests still waiting for the fetch, which is cancelled once there are none left. */
+	waiters int
+	ctx     *inFlightFetchContext
+}
+
+/* inFlightImageFetches coalesces fetches of the same image that happen at the same time. */
+type inFlightImageFetches struct {
+	mutex   sync.Mutex
+	fetches map[string]*inFlightImageFetch
+}
+
+/* NewInFlightImageFetches returns a new InFlightImageFetches. */
+func NewInFlightImageFetches() InFlightImageFetches {
+	return &inFlightImageFetches{fetches: make(map[string]*inFlightImageFetch)}
+}
+
+/*
+Do calls fetch for the uuid unless a fetch for the same uuid is already in flight, in which case it waits for and
+returns the image / error of that one instead.
+
+The fetch runs on it's own context rather than that of the request that started it, as the requests that joined later
+may well be waiting for longer. It's deadline is the latest of the requests waiting for it, extended as requests with
+later deadlines join, and it is cancelled once every request waiting for it has given up. A fetch that has already run
+out of time is not joined, a new one is started in it's place.
+*/
+func (f *inFlightImageFetches) Do(
+	ctx context.Context,
+	imageUuid *Domain.ImageUuid,
+	fetch func(ctx context.Context) (Domain.StreetViewImage, error),
+) (Domain.StreetViewImage, error) {
+	if err := ApiClient.NewRequestContextError(ctx); err != nil {
+		return nil, err
+	}
+
+	key := imageUuid.String()
+
+	f.mutex.Lock()
+
+	inFlight, exists := f.fetches[key]
+
+	if !exists || inFlight.ctx.Err() != nil {
+		inFlight = &inFlightImageFetch{done: make(chan struct{}), ctx: newInFlightFetchContext()}
+		inFlight.ctx.extendDeadline(ctx)
+		f.fetches[key] = inFlight
+
+		go f.fetch(inFlight.ctx, key, inFlight, fetch)
+	} else {
+		inFlight.ctx.extendDeadline(ctx)
+	}
+
+	inFlight.waiters++
+
+	f.mutex.Unlock()
+
+	select {
+	case <-inFlight.done:
+		return inFlight.image, inFlight.err
+	case <-ctx.Done():
+		f.stopWaiting(key, inFlight)
+
+		return nil, ApiClient.NewRequestContextError(ctx)
+	}
+}
+
+/*
+fetch performs the fetch of an in flight image, releasing it's waiters and forgetting about it once it completes. A
+panicking fetch is recovered from as it's no longer running in the goroutine of a request.
+*/
+func (f *inFlightImageFetches) fetch(
+	ctx context.Context,
+	key string,
+	inFlight *inFlightImageFetch,
+	fetch func(ctx context.Context) (Domain.StreetViewImage, error),
+) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			inFlight.image, inFlight.err = nil, Error.NewApplicationError(
+				fmt.Sprintf("The in flight fetch of this image panicked: '%v'", recovered),
+			)
+		}
+
+		f.forget(key, inFlight)
+		inFlight.ctx.cancel()
+
+		close(inFlight.done)
+	}()
+
+	inFlight.image, inFlight.err = fetch(ctx)
+}
+
+/*
+stopWaiting stops a request waiting for the in flight fetch, cancelling the fetch if it was the last request waiting. A
+cancelled fetch is forgotten straight away so that a request for the same image arriving next starts a new one.
+*/
+func (f *inFlightImageFetches) stopWaiting(key string, inFlight *inFlightImageFetch) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	inFlight.waiters--
+
+	if inFlight.waiters > 0 {
+		return
+	}
+
+	inFlight.ctx.cancel()
+
+	if f.fetches[key] == inFlight {
+		delete(f.fetches, key)
+	}
+}
+
+/* forget forgets about the in flight fetch, unless a new fetch for the same key has already replaced it. */
+func (f *inFlightImageFetches) forget(key string, inFlight *inFlightImageFetch) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.fetches[key] == inFlight {
+		delete(f.fetches, key)
+	}
+}
+
+/*
+inFlightFetchContext is the context an in flight fetch runs on. Unlike a context.WithDeadline it's deadline can be
+extended, as a request with a later deadline than those already waiting may join the fetch at any time.
+*/
+type inFlightFetchContext struct {
+	context.Context
+	cancelFunc context.CancelFunc
+
+	mutex sync.Mutex
+	/* deadline is the latest deadline of the requests waiting, zero until one has joined or once one has none. */
+	deadline time.Time
+	/* unbounded is true once a request without a deadline has joined, as it is willing to wait for as long as it takes. */
+	unbounded bool
+	/* timer expires the context at the deadline, replaced whenever the deadline is extended. */
+	timer *time.Timer
+	/* expired is true once the deadline has passed, so that Err returns context.DeadlineExceeded. */
+	expired bool
+}
+
+/* newInFlightFetchContext returns a new inFlightFetchContext without a deadline until a request joins. */
+func newInFlightFetchContext() *inFlightFetchContext {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &inFlightFetchContext{Context: ctx, cancelFunc: cancel}
+}
+
+/* Deadline returns the latest deadline of the requests waiting, if they all have one, see: context.Context. */
+func (c *inFlightFetchContext) Deadline() (time.Time, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.unbounded || c.deadline.IsZero() {
+		return time.Time{}, false
+	}
+
+	return c.deadline, true
+}
+
+/* Err returns context.DeadlineExceeded once the deadline has passed, otherwise why it was cancelled if it was. */
+func (c *inFlightFetchContext) Err() error {
+	err := c.Context.Err()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil && c.expired {
+		return context.DeadlineExceeded
+	}
+
+	return err
+}
+
+/*
+extendDeadline extends the deadline to that of the context of a request joining, when it is later. A request without a
+deadline removes it altogether.
+*/
+func (c *inFlightFetchContext) extendDeadline(ctx context.Context) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.unbounded {
+		return
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+
+	if !hasDeadline {
+		c.unbounded = true
+		c.stopTimer()
+
+		return
+	}
+
+	if !deadline.After(c.deadline) {
+		return
+	}
+
+	c.deadline = deadline
+	c.stopTimer()
+	c.timer = time.AfterFunc(time.Until(deadline), c.expire)
+}
+
+/* expire cancels the context with context.DeadlineExceeded, unless the deadline was extended in the meantime. */
+func (c *inFlightFetchContext) expire() {
+	c.mutex.Lock()
+
+	if c.unbounded || time.Now().Before(c.deadline) {
+		c.mutex.Unlock()
+
+		return
+	}
+
+	c.expired = true
+
+	c.mutex.Unlock()
+
+	c.cancelFunc()
+}
+
+/* cancel cancels the context, releasing it's timer. */
+func (c *inFlightFetchContext) cancel() {
+	c.mutex.Lock()
+	c.stopTimer()
+	c.mutex.Unlock()
+
+	c.cancelFunc()
+}
+
+/* stopTimer stops the timer expiring the context, if there is one. The mutex must be held. */
+func (c *inFlightFetchContext) stopTimer() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
@@ -0,0 +1,366 @@
+package QueryHandler
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"app/test/FakeStreetView"
+	"bytes"
+	"context"
+	"github.com/j7mbo/goenvconfig"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+testRateLimiter queues every call for the wait, so that tests do not need redis. Like the limiter in redis it fails
+straight away when the wait would not end before the deadline.
+*/
+type testRateLimiter struct {
+	wait time.Duration
+}
+
+func (l testRateLimiter) Wait(ctx context.Context, calls int, deadline time.Time) error {
+	if time.Now().Add(l.wait).After(deadline) {
+		return Error.UserError{Code: ApiClient.RateLimitExceededCode, Err: ApiClient.RateLimitExceededErr}
+	}
+
+	time.Sleep(l.wait)
+
+	return nil
+}
+
+/* testBudget never runs out, so that tests do not need redis. */
+type testBudget struct{}
+
+func (testBudget) Reserve(ctx context.Context) (ApiClient.StreetViewApiReservation, error) {
+	return ApiClient.StreetViewApiReservation{}, nil
+}
+
+func (testBudget) Release(reservation ApiClient.StreetViewApiReservation) {}
+
+func (testBudget) GetUsage(ctx context.Context) (*ApiClient.StreetViewApiUsage, error) {
+	return &ApiClient.StreetViewApiUsage{}, nil
+}
+
+/*
+newTestApiClient returns a client for google's api calling the fake at the endpoint within the rate limiter, with the
+variables given set on top of a configuration that retries immediately and never opens the breaker.
+*/
+func newTestApiClient(
+	t *testing.T, endpoint string, variables map[string]string, limiter ApiClient.StreetViewApiRateLimiter,
+) ApiClient.StreetViewApiClient {
+	apiConfig := config.StreetViewApiConfiguration{}
+
+	defaults := map[string]string{
+		"STREETVIEW_API_ENDPOINT":                  endpoint,
+		"STREETVIEW_API_KEY":                       "key",
+		"STREETVIEW_API_MAX_RETRIES":               "3",
+		"STREETVIEW_API_RETRY_DELAY":               "0",
+		"STREETVIEW_API_BREAKER_FAILURE_THRESHOLD": "0",
+	}
+
+	for name, value := range defaults {
+		if _, isSet := variables[name]; !isSet {
+			t.Setenv(name, value)
+		}
+	}
+
+	for name, value := range variables {
+		t.Setenv(name, value)
+	}
+
+	if err := goenvconfig.NewGoEnvParser().Parse(&apiConfig); err != nil {
+		t.Fatalf("Unable to parse the test configuration, error: '%s'", err.Error())
+	}
+
+	logger := Logger.LoggingStrategy{}
+
+	return ApiClient.NewStreetViewApiClient(
+		apiConfig,
+		config.MapillaryConfiguration{},
+		ApiClient.RetrierFactory{},
+		http.DefaultClient,
+		ApiClient.NewStreetViewApiKeys(apiConfig, &logger),
+		logger,
+		ApiClient.NewStreetViewApiStatus(),
+		ApiClient.NewStreetViewApiCircuitBreaker(apiConfig, &logger),
+		limiter,
+		testBudget{},
+	)
+}
+
+/* newTestImageUuid returns the uuid of an image of the default size at the location. */
+func newTestImageUuid(latitude float64, longitude float64) *Domain.ImageUuid {
+	return Domain.NewImageUuid(latitude, longitude, Domain.NewImageParameters(nil, nil, nil, nil, ""))
+}
+
+/* requestTestImage returns a fetch requesting the image of the uuid from the api client. */
+func requestTestImage(
+	client ApiClient.StreetViewApiClient, imageUuid *Domain.ImageUuid,
+) func(ctx context.Context) (Domain.StreetViewImage, error) {
+	return func(ctx context.Context) (Domain.StreetViewImage, error) {
+		latitude, longitude := imageUuid.GetLatitude(), imageUuid.GetLongitude()
+
+		apiImage, err := client.Request(ctx, latitude, longitude, imageUuid.GetParameters())
+
+		if err != nil {
+			return nil, err
+		}
+
+		return Domain.NewStreetViewImageFromUuid(imageUuid, apiImage.Bytes, apiImage.Provider)
+	}
+}
+
+func TestInFlightImageFetchDoesNotWaitToRetryPastTheDeadline(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{StatusCode: 503, RetryAfter: 2})
+
+	client := newTestApiClient(t, endpoint, map[string]string{}, testRateLimiter{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	imageUuid := newTestImageUuid(51.5, -0.1)
+	start := time.Now()
+
+	_, err := NewInFlightImageFetches().Do(ctx, imageUuid, requestTestImage(client, imageUuid))
+
+	/* The fetch gives up by itself rather than being abandoned at the deadline, waiting to retry being pointless. */
+	if _, isApplicationErr := err.(Error.ApplicationError); !isApplicationErr {
+		t.Fatalf("Expected an application error, got: '%v'", err)
+	}
+
+	if !strings.Contains(err.Error(), "waiting to retry would exceed the deadline of the request") {
+		t.Errorf("Expected the retry to be given up on for the deadline, got: '%s'", err.Error())
+	}
+
+	if took := time.Since(start); took >= 500*time.Millisecond {
+		t.Errorf("Expected to give up before the deadline, took: '%s'", took)
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 1 {
+		t.Errorf("Expected the metadata to be requested once, got: '%d'", count)
+	}
+}
+
+func TestInFlightImageFetchExceedsTheRateLimitBeforeTheDeadline(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	/* The request would be queued for the rate limit for longer than it's deadline, but not the max wait. */
+	client := newTestApiClient(t, endpoint, map[string]string{
+		"STREETVIEW_API_RATE_LIMIT_MAX_WAIT": "5",
+	}, testRateLimiter{wait: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	imageUuid := newTestImageUuid(51.5, -0.1)
+
+	_, err := NewInFlightImageFetches().Do(ctx, imageUuid, requestTestImage(client, imageUuid))
+
+	if userErr, isUserErr := err.(Error.UserError); !isUserErr || userErr.Code != ApiClient.RateLimitExceededCode {
+		t.Errorf("Expected a user error with code: '%s', got: '%v'", ApiClient.RateLimitExceededCode, err)
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 0 {
+		t.Errorf("Expected no requests to be made, got: '%d' metadata requests", count)
+	}
+}
+
+func TestInFlightImageFetchDeadlineIsExtendedByALaterWaiter(t *testing.T) {
+	fetches := NewInFlightImageFetches()
+	imageUuid := newTestImageUuid(51.5, -0.1)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	deadlines := make(chan time.Time, 1)
+
+	shortCtx, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+
+	longCtx, cancelLong := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelLong()
+
+	go func() {
+		_, _ = fetches.Do(shortCtx, imageUuid, func(ctx context.Context) (Domain.StreetViewImage, error) {
+			close(started)
+			<-release
+
+			deadline, _ := ctx.Deadline()
+			deadlines <- deadline
+
+			return nil, ApiClient.NewRequestContextError(ctx)
+		})
+	}()
+
+	<-started
+
+	result := make(chan error, 1)
+
+	go func() {
+		_, err := fetches.Do(longCtx, imageUuid, nil)
+		result <- err
+	}()
+
+	/* Long enough for the later waiter to have joined, and for the first one's deadline to have passed. */
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	longDeadline, _ := longCtx.Deadline()
+
+	if deadline := <-deadlines; !deadline.Equal(longDeadline) {
+		t.Errorf("Expected the deadline of the later waiter: '%s', got: '%s'", longDeadline, deadline)
+	}
+
+	if err := <-result; err != nil {
+		t.Errorf("Expected the fetch to still be running for the later waiter, got: '%s'", err.Error())
+	}
+}
+
+/* fetchConcurrently calls Do for the uuid from each of the waiters at once, returning the image and error of each. */
+func fetchConcurrently(
+	fetches InFlightImageFetches,
+	imageUuid *Domain.ImageUuid,
+	fetch func(ctx context.Context) (Domain.StreetViewImage, error),
+	waiters int,
+) ([]Domain.StreetViewImage, []error) {
+	images, errs := make([]Domain.StreetViewImage, waiters), make([]error, waiters)
+
+	var group sync.WaitGroup
+
+	for i := 0; i < waiters; i++ {
+		group.Add(1)
+
+		go func(i int) {
+			defer group.Done()
+
+			images[i], errs[i] = fetches.Do(context.Background(), imageUuid, fetch)
+		}(i)
+	}
+
+	group.Wait()
+
+	return images, errs
+}
+
+func TestInFlightImageFetchIsMadeOnceForConcurrentRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario FakeStreetView.Scenario
+		isErr    bool
+	}{
+		{name: "image", scenario: FakeStreetView.Scenario{DelayMillis: 200}},
+		{name: "error", scenario: FakeStreetView.Scenario{Status: "ZERO_RESULTS", DelayMillis: 200}, isErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+			defer server.Close()
+
+			/* Slow enough for every request to have joined the fetch before it completes. */
+			test.scenario.Endpoint = FakeStreetView.EndpointMetadata
+			fake.Script(test.scenario)
+
+			client := newTestApiClient(t, endpoint, map[string]string{}, testRateLimiter{})
+			imageUuid := newTestImageUuid(51.5, -0.1)
+			fetch := requestTestImage(client, imageUuid)
+
+			images, errs := fetchConcurrently(NewInFlightImageFetches(), imageUuid, fetch, 10)
+
+			for _, endpoint := range []string{FakeStreetView.EndpointMetadata, FakeStreetView.EndpointImage} {
+				expected := 1
+
+				if test.isErr && endpoint == FakeStreetView.EndpointImage {
+					expected = 0
+				}
+
+				if count := fake.GetRequestCount(endpoint); count != expected {
+					t.Errorf("Expected '%d' %s requests, got: '%d'", expected, endpoint, count)
+				}
+			}
+
+			for i := range images {
+				if (errs[i] != nil) != test.isErr {
+					t.Fatalf("Expected an error: '%t', got: '%v'", test.isErr, errs[i])
+				}
+
+				if test.isErr && errs[i].Error() != errs[0].Error() {
+					t.Errorf("Expected the same error: '%s', got: '%s'", errs[0].Error(), errs[i].Error())
+				}
+
+				if !test.isErr && !bytes.Equal(images[i].GetBytes(), images[0].GetBytes()) {
+					t.Errorf("Expected the same image for every request, request: '%d' got a different one", i)
+				}
+			}
+		})
+	}
+}
+
+func TestInFlightImageFetchIsCancelledOnceTheLastRequestStopsWaiting(t *testing.T) {
+	fetches := NewInFlightImageFetches()
+	imageUuid := newTestImageUuid(51.5, -0.1)
+
+	started, cancelled := make(chan struct{}), make(chan error, 1)
+
+	fetch := func(ctx context.Context) (Domain.StreetViewImage, error) {
+		close(started)
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+
+		return nil, ApiClient.NewRequestContextError(ctx)
+	}
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	lastCtx, cancelLast := context.WithCancel(context.Background())
+	defer cancelLast()
+
+	results := make(chan error, 2)
+
+	go func() {
+		_, err := fetches.Do(firstCtx, imageUuid, fetch)
+		results <- err
+	}()
+
+	<-started
+
+	go func() {
+		_, err := fetches.Do(lastCtx, imageUuid, fetch)
+		results <- err
+	}()
+
+	/* Long enough for the last request to have joined. */
+	time.Sleep(50 * time.Millisecond)
+	cancelFirst()
+
+	if err := <-results; err == nil {
+		t.Error("Expected the request that stopped waiting to fail, got no error")
+	}
+
+	select {
+	case err := <-cancelled:
+		t.Fatalf("Expected the fetch to keep running for the last request, got cancelled: '%v'", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancelLast()
+
+	select {
+	case err := <-cancelled:
+		if err != context.Canceled {
+			t.Errorf("Expected the fetch to be cancelled, got: '%v'", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the fetch to be cancelled once the last request stopped waiting")
+	}
+
+	<-results
+}
@@ -0,0 +1,108 @@
+package QueryHandler
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"context"
+	"fmt"
+)
+
+/*
+streetViewImageFetcher retrieves an image from Google StreetView when it could not be found in the repository and saves
+it for next time. It is shared by the handlers that have to fetch images so that they all behave the same on a miss.
+*/
+type streetViewImageFetcher struct {
+	repository Domain.StreetViewImages
+	apiClient  ApiClient.StreetViewApiClient
+	inFlight   InFlightImageFetches
+}
+
+/* newStreetViewImageFetcher returns a new streetViewImageFetcher. */
+func newStreetViewImageFetcher(
+	repository Domain.StreetViewImages, apiClient ApiClient.StreetViewApiClient, inFlight InFlightImageFetches,
+) *streetViewImageFetcher {
+	return &streetViewImageFetcher{repository: repository, apiClient: apiClient, inFlight: inFlight}
+}
+
+/*
+Fetch requests the image identified by the uuid from the api, validates it and saves it to the repository. A uuid with a
+pano id is requested by that panorama, otherwise by it's location.
+
+Concurrent fetches of the same uuid are coalesced into one api request, all of them receiving the same image / error.
+*/
+func (f *streetViewImageFetcher) Fetch(
+	ctx context.Context, imageUuid *Domain.ImageUuid,
+) (Domain.StreetViewImage, error) {
+	return f.inFlight.Do(ctx, imageUuid, func(ctx context.Context) (Domain.StreetViewImage, error) {
+		return f.fetch(ctx, imageUuid)
+	})
+}
+
+/*
+fetch requests the image identified by the uuid from the api, validates it and saves it to the repository.
+
+Locations (and panoramas) known to have no image are not requested again until the repository forgets about them.
+*/
+func (f *streetViewImageFetcher) fetch(
+	ctx context.Context, imageUuid *Domain.ImageUuid,
+) (Domain.StreetViewImage, error) {
+	if f.repository.HasNoCoverage(ctx, imageUuid) {
+		return nil, newNoCoverageError(imageUuid)
+	}
+
+	apiImage, err := f.request(ctx, imageUuid)
+
+	if err != nil {
+		/* Assertion here as we only know an image doesn't exist in streetview (user's fault) when in the domain. */
+		if userError, isUserError := err.(Error.UserError); isUserError {
+			if isNoCoverageError(userError) {
+				f.repository.SaveNoCoverage(ctx, imageUuid)
+			}
+
+			return nil, err
+		}
+
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Unable to perform request to streetview API, error: %s", err.Error()),
+		)
+	}
+
+	image, err := Domain.NewStreetViewImageFromUuid(imageUuid, apiImage.Bytes, apiImage.Provider)
+
+	if err != nil {
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Looks like the StreetView api image was not considered a valid image. Error: %s", err.Error()),
+		)
+	}
+
+	/* The image has been paid for by now, so it is saved even if every request waiting for it has given up. */
+	image.Save(context.WithoutCancel(ctx), f.repository)
+
+	return image, nil
+}
+
+/* request performs the api request for the image identified by the uuid. */
+func (f *streetViewImageFetcher) request(
+	ctx context.Context, imageUuid *Domain.ImageUuid,
+) (*ApiClient.StreetViewApiImage, error) {
+	if imageUuid.GetPanoId() != "" {
+		return f.apiClient.RequestByPanoId(ctx, imageUuid.GetPanoId(), imageUuid.GetParameters())
+	}
+
+	return f.apiClient.Request(ctx, imageUuid.GetLatitude(), imageUuid.GetLongitude(), imageUuid.GetParameters())
+}
+
+/* newNoCoverageError returns the error the api client returns when there is no image for the uuid. */
+func newNoCoverageError(imageUuid *Domain.ImageUuid) Error.UserError {
+	if imageUuid.GetPanoId() != "" {
+		return Error.UserError{Code: ApiClient.InvalidPanoIdCode, Err: ApiClient.InvalidPanoIdErr}
+	}
+
+	return Error.UserError{Code: ApiClient.InvalidLocationCode, Err: ApiClient.InvalidLocationCodeErr}
+}
+
+/* isNoCoverageError returns whether the error is the api client saying there is no image, as opposed to any other. */
+func isNoCoverageError(err Error.UserError) bool {
+	return err.Code == ApiClient.InvalidLocationCode || err.Code == ApiClient.InvalidPanoIdCode
+}
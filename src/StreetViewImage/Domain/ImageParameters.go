@@ -0,0 +1,159 @@
+package Domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* Sources an image can be limited to, the values are those the StreetView API expects for it's "source" parameter. */
+const (
+	SourceDefault = "default"
+	SourceOutdoor = "outdoor"
+)
+
+/*
+ProviderDefault is the imagery provider images are retrieved from unless configured otherwise. It is left out of the
+uuid of an image so that images retrieved from it are identified the same way they were before providers existed.
+*/
+const ProviderDefault = "google"
+
+/*
+ImageParameters contains the optional parameters used to frame a StreetViewImage (which way the camera faces etc).
+
+A nil parameter means it was not provided, so the API default is used instead.
+*/
+type ImageParameters struct {
+	heading *float64
+	pitch   *float64
+	fov     *int
+	radius  *int
+	source  string
+	width   *int
+	height  *int
+	scale   *int
+	/* provider is the imagery provider the image is retrieved from, so that imagery from different ones never mixes. */
+	provider string
+}
+
+/* NewImageParameters returns new ImageParameters; an empty source is considered to be SourceDefault. */
+func NewImageParameters(heading *float64, pitch *float64, fov *int, radius *int, source string) *ImageParameters {
+	if source == "" {
+		source = SourceDefault
+	}
+
+	return &ImageParameters{heading: heading, pitch: pitch, fov: fov, radius: radius, source: source}
+}
+
+/*
+WithSize returns a copy of the parameters with the size of the image set, rather than the configured size. The heading,
+pitch etc. are shared with the copy.
+*/
+func (p *ImageParameters) WithSize(width int, height int) *ImageParameters {
+	parameters := *p
+	parameters.width, parameters.height = &width, &height
+
+	return &parameters
+}
+
+/* WithScale returns a copy of the parameters with the scale (pixel density) of the image set. */
+func (p *ImageParameters) WithScale(scale int) *ImageParameters {
+	parameters := *p
+	parameters.scale = &scale
+
+	return &parameters
+}
+
+/* WithProvider returns a copy of the parameters with the imagery provider the image is retrieved from set. */
+func (p *ImageParameters) WithProvider(provider string) *ImageParameters {
+	parameters := *p
+	parameters.provider = provider
+
+	return &parameters
+}
+
+/* GetHeading retrieves the compass heading of the camera, or nil if the API should calculate it. */
+func (p *ImageParameters) GetHeading() *float64 {
+	return p.heading
+}
+
+/* GetPitch retrieves the up or down angle of the camera, or nil for the API default. */
+func (p *ImageParameters) GetPitch() *float64 {
+	return p.pitch
+}
+
+/* GetFov retrieves the horizontal field of view of the image, or nil for the configured default. */
+func (p *ImageParameters) GetFov() *int {
+	return p.fov
+}
+
+/* GetRadius retrieves the radius in meters in which to search for an image, or nil for the API default. */
+func (p *ImageParameters) GetRadius() *int {
+	return p.radius
+}
+
+/* GetSource retrieves the source the search for an image is limited to. */
+func (p *ImageParameters) GetSource() string {
+	return p.source
+}
+
+/* GetWidth retrieves the width of the image in pixels, or nil for the configured width. */
+func (p *ImageParameters) GetWidth() *int {
+	return p.width
+}
+
+/* GetHeight retrieves the height of the image in pixels, or nil for the configured height. */
+func (p *ImageParameters) GetHeight() *int {
+	return p.height
+}
+
+/* GetScale retrieves the scale (pixel density) of the image, or nil for the API default of 1. */
+func (p *ImageParameters) GetScale() *int {
+	return p.scale
+}
+
+/* GetProvider retrieves the imagery provider the image is retrieved from, empty when it was not set. */
+func (p *ImageParameters) GetProvider() string {
+	return p.provider
+}
+
+/*
+String returns only the provided parameters as a string, so images requested without any parameters are identified the
+same way they were before parameters existed.
+*/
+func (p *ImageParameters) String() string {
+	var parameters []string
+
+	if p.heading != nil {
+		parameters = append(parameters, fmt.Sprintf("heading=%f", *p.heading))
+	}
+
+	if p.pitch != nil {
+		parameters = append(parameters, fmt.Sprintf("pitch=%f", *p.pitch))
+	}
+
+	if p.fov != nil {
+		parameters = append(parameters, fmt.Sprintf("fov=%d", *p.fov))
+	}
+
+	if p.radius != nil {
+		parameters = append(parameters, fmt.Sprintf("radius=%d", *p.radius))
+	}
+
+	if p.width != nil && p.height != nil {
+		parameters = append(parameters, fmt.Sprintf("size=%dx%d", *p.width, *p.height))
+	}
+
+	if p.scale != nil {
+		parameters = append(parameters, fmt.Sprintf("scale=%d", *p.scale))
+	}
+
+	if p.source != SourceDefault {
+		parameters = append(parameters, fmt.Sprintf("source=%s", p.source))
+	}
+
+	if p.provider != "" && p.provider != ProviderDefault {
+		parameters = append(parameters, fmt.Sprintf("provider=%s", p.provider))
+	}
+
+	return strings.Join(parameters, ":")
+}
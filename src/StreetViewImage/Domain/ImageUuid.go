@@ -8,26 +8,120 @@ import (
 /* uuidString is the format for the unique identifier of a StreetViewImage. */
 const uuidString = "street_view_image:{image.latitude}:{image.longitude}"
 
+/* panoUuidString is the format for the unique identifier of a StreetViewImage requested by it's panorama id. */
+const panoUuidString = "street_view_pano_image:{image.pano}"
+
+/* panoramaUuidString is the format for the unique identifier of a stitched 360 degree panorama of a location. */
+const panoramaUuidString = "street_view_panorama:{image.latitude}:{image.longitude}:slices={image.slices}"
+
 /* ImageUuid is a unique identifier for a StreetViewImage. It may be used for persistence and is re-constructable. */
 type ImageUuid struct {
 	uuidString string
+	latitude   float64
+	longitude  float64
+	panoId     string
+	slices     int
+	parameters *ImageParameters
 }
 
 /*
-NewImageUuid creates a new uuid given a latitude and longitude.
+NewImageUuid creates a new uuid given a latitude, longitude and the parameters the image was framed with.
 
 We deliberately don't pass a StreetViewImage here for easy reconstruction purposes (no domain object required).
 */
-func NewImageUuid(latitude float64, longitude float64) *ImageUuid {
+func NewImageUuid(latitude float64, longitude float64, parameters *ImageParameters) *ImageUuid {
 	latitudeString := fmt.Sprintf("%f", latitude)
 	longitudeString := fmt.Sprintf("%f", longitude)
 
 	replacer := strings.NewReplacer("{image.latitude}", latitudeString, "{image.longitude}", longitudeString)
 
-	return &ImageUuid{uuidString: replacer.Replace(uuidString)}
+	uuid := appendParameters(replacer.Replace(uuidString), parameters)
+
+	return &ImageUuid{uuidString: uuid, latitude: latitude, longitude: longitude, parameters: parameters}
+}
+
+/*
+NewPanoImageUuid creates a new uuid given a panorama id and the parameters the image was framed with.
+
+Images requested by panorama id are identified separately to those requested by location, even though they may end up
+being the same panorama, as there is no way of knowing which panorama a location resolves to without asking Google.
+*/
+func NewPanoImageUuid(panoId string, parameters *ImageParameters) *ImageUuid {
+	uuid := appendParameters(strings.Replace(panoUuidString, "{image.pano}", panoId, 1), parameters)
+
+	return &ImageUuid{uuidString: uuid, panoId: panoId, parameters: parameters}
+}
+
+/*
+NewPanoramaImageUuid creates a new uuid given a latitude, longitude, the number of slices the panorama was stitched from
+and the parameters each slice was requested with (except the heading and fov which are derived from the slices).
+*/
+func NewPanoramaImageUuid(latitude float64, longitude float64, slices int, parameters *ImageParameters) *ImageUuid {
+	replacer := strings.NewReplacer(
+		"{image.latitude}", fmt.Sprintf("%f", latitude),
+		"{image.longitude}", fmt.Sprintf("%f", longitude),
+		"{image.slices}", fmt.Sprintf("%d", slices),
+	)
+
+	uuid := appendParameters(replacer.Replace(panoramaUuidString), parameters)
+
+	return &ImageUuid{
+		uuidString: uuid, latitude: latitude, longitude: longitude, slices: slices, parameters: parameters,
+	}
+}
+
+/* GetLatitude retrieves the latitude the uuid was created with, so the image can be reconstructed. */
+func (i *ImageUuid) GetLatitude() float64 {
+	return i.latitude
+}
+
+/* GetLongitude retrieves the longitude the uuid was created with, so the image can be reconstructed. */
+func (i *ImageUuid) GetLongitude() float64 {
+	return i.longitude
+}
+
+/* GetPanoId retrieves the panorama id the uuid was created with, empty when the image was requested by location. */
+func (i *ImageUuid) GetPanoId() string {
+	return i.panoId
+}
+
+/* GetSlices retrieves the number of slices a panorama was stitched from, zero when the image is not a panorama. */
+func (i *ImageUuid) GetSlices() int {
+	return i.slices
+}
+
+/* GetParameters retrieves the parameters the uuid was created with, so the image can be reconstructed. */
+func (i *ImageUuid) GetParameters() *ImageParameters {
+	return i.parameters
+}
+
+/*
+GetCoverageUuid retrieves the uuid of the panorama lookup behind the image: it's location (or panorama id) and only the
+parameters affecting which panorama is found (and where from). Differently framed images, and panoramas, of a location
+share it.
+*/
+func (i *ImageUuid) GetCoverageUuid() *ImageUuid {
+	parameters := NewImageParameters(
+		nil, nil, nil, i.parameters.GetRadius(), i.parameters.GetSource(),
+	).WithProvider(i.parameters.GetProvider())
+
+	if i.panoId != "" {
+		return NewPanoImageUuid(i.panoId, parameters)
+	}
+
+	return NewImageUuid(i.latitude, i.longitude, parameters)
 }
 
 /* String returns the uuid as a string, useful for persistence. */
 func (i *ImageUuid) String() string {
 	return i.uuidString
 }
+
+/* appendParameters ensures differently framed images do not collide, defaults keep the original format. */
+func appendParameters(uuid string, parameters *ImageParameters) string {
+	if parametersString := parameters.String(); parametersString != "" {
+		return fmt.Sprintf("%s:%s", uuid, parametersString)
+	}
+
+	return uuid
+}
@@ -1,9 +1,11 @@
 package Domain
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 /* expectedImageType is the content type the StreetView API specifies it will return; used for validation purposes. */
@@ -14,10 +16,18 @@ type StreetViewImage interface {
 	GetUuid() string
 	GetLatitude() float64
 	GetLongitude() float64
+	GetPanoId() string
+	GetParameters() *ImageParameters
 	GetBytes() []byte
 
+	/* GetProvider retrieves the name of the imagery provider the image was retrieved from. */
+	GetProvider() string
+
+	/* GetCreatedAt retrieves when the image was retrieved from the StreetView API, to the second. */
+	GetCreatedAt() time.Time
+
 	/* Save saves an image for future use. Technically this is caching it. Here's your DDD-style stuff -.-. */
-	Save(images StreetViewImages)
+	Save(ctx context.Context, images StreetViewImages)
 }
 
 /* streetViewImage contains the raw data of an image from Google StreetView. */
@@ -25,18 +35,53 @@ type streetViewImage struct {
 	uuid       *ImageUuid
 	latitude   float64
 	longitude  float64
+	panoId     string
+	parameters *ImageParameters
 	imageBytes []byte
+	createdAt  time.Time
+	provider   string
+}
+
+/*
+NewStreetViewImage returns an initialised StreetViewImage retrieved from the provider or an error if the image was
+considered invalid.
+*/
+func NewStreetViewImage(
+	latitude float64, longitude float64, parameters *ImageParameters, byteArray []byte, provider string,
+) (StreetViewImage, error) {
+	return NewStreetViewImageFromUuid(NewImageUuid(latitude, longitude, parameters), byteArray, provider)
 }
 
-/* NewStreetViewImage returns an initialised StreetViewImage or an error if the image was considered invalid. */
-func NewStreetViewImage(latitude float64, longitude float64, byteArray []byte) (StreetViewImage, error) {
+/*
+NewStreetViewImageFromUuid returns an initialised StreetViewImage identified by the given uuid and retrieved from the
+provider, or an error if the image was considered invalid. Useful for images requested by panorama id and for
+reconstructing images from persistence.
+*/
+func NewStreetViewImageFromUuid(uuid *ImageUuid, byteArray []byte, provider string) (StreetViewImage, error) {
+	return NewStoredStreetViewImage(uuid, byteArray, time.Now(), provider)
+}
+
+/*
+NewStoredStreetViewImage returns an initialised StreetViewImage identified by the given uuid that was retrieved from the
+provider at createdAt, or an error if the image was considered invalid. For reconstructing images from storage.
+*/
+func NewStoredStreetViewImage(
+	uuid *ImageUuid, byteArray []byte, createdAt time.Time, provider string,
+) (StreetViewImage, error) {
 	if err := validateImage(byteArray); err != nil {
 		return nil, err
 	}
 
-	uuid := NewImageUuid(latitude, longitude)
-
-	return &streetViewImage{uuid: uuid, latitude: latitude, longitude: longitude, imageBytes: byteArray}, nil
+	return &streetViewImage{
+		uuid:       uuid,
+		latitude:   uuid.GetLatitude(),
+		longitude:  uuid.GetLongitude(),
+		panoId:     uuid.GetPanoId(),
+		parameters: uuid.GetParameters(),
+		imageBytes: byteArray,
+		createdAt:  createdAt.Truncate(time.Second),
+		provider:   provider,
+	}, nil
 }
 
 /* GetUuid returns the uuid for this image as a string. */
@@ -49,6 +94,16 @@ func (i *streetViewImage) GetBytes() []byte {
 	return i.imageBytes
 }
 
+/* GetCreatedAt retrieves when the image was retrieved from the StreetView API, to the second. */
+func (i *streetViewImage) GetCreatedAt() time.Time {
+	return i.createdAt
+}
+
+/* GetProvider retrieves the name of the imagery provider the image was retrieved from. */
+func (i *streetViewImage) GetProvider() string {
+	return i.provider
+}
+
 /* GetLatitude retrieves the latitude. */
 func (i *streetViewImage) GetLatitude() float64 {
 	return i.latitude
@@ -59,10 +114,20 @@ func (i *streetViewImage) GetLongitude() float64 {
 	return i.longitude
 }
 
+/* GetPanoId retrieves the panorama id, only available when the image was requested by panorama id. */
+func (i *streetViewImage) GetPanoId() string {
+	return i.panoId
+}
+
+/* GetParameters retrieves the parameters the image was framed with. */
+func (i *streetViewImage) GetParameters() *ImageParameters {
+	return i.parameters
+}
+
 /* Save saves an image for future use. */
-func (i *streetViewImage) Save(images StreetViewImages) {
+func (i *streetViewImage) Save(ctx context.Context, images StreetViewImages) {
 	/* It doesn't really matter if this fails, this is optional and is already logged. */
-	_ = images.Save(i)
+	_ = images.Save(ctx, i)
 }
 
 /* validateImage uses http.DetectContentType to ensure that the image is of type image/jpeg as the API specifies. */
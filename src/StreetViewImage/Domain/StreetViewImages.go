@@ -1,16 +1,51 @@
 package Domain
 
-/* StreetViewImages represents a repository capable of retrieving images from persistence (cache in our case). */
+import "context"
+
+/*
+StreetViewImages represents a repository capable of retrieving images from persistence (cache in our case). The context
+is that of the request, persistence giving up on it once it is done.
+*/
 type StreetViewImages interface {
 	/*
 	   Save stores the image in persistence and returns whether or not this storing was successful.
 
 	   Why it might be unsuccessful is not the client's concern.
 	*/
-	Save(image StreetViewImage) bool
+	Save(ctx context.Context, image StreetViewImage) bool
 
 	/*
 	   Find retrieves an image from persistence if one exists.
 	*/
-	Find(latitude float64, longitude float64) StreetViewImage
+	Find(ctx context.Context, latitude float64, longitude float64, parameters *ImageParameters) StreetViewImage
+
+	/*
+	   FindByPanoId retrieves an image requested by it's panorama id from persistence if one exists.
+	*/
+	FindByPanoId(ctx context.Context, panoId string, parameters *ImageParameters) StreetViewImage
+
+	/*
+	   FindPanorama retrieves a stitched panorama of a location from persistence if one exists.
+	*/
+	FindPanorama(
+		ctx context.Context, latitude float64, longitude float64, slices int, parameters *ImageParameters,
+	) StreetViewImage
+
+	/*
+	   FindMany retrieves multiple images from persistence at once, returned in the same order as the uuids provided.
+
+	   Images that do not exist in persistence are nil in the returned slice.
+	*/
+	FindMany(ctx context.Context, uuids []*ImageUuid) []StreetViewImage
+
+	/*
+	   SaveNoCoverage records that there is no image for the location (or panorama) of the uuid, for a shorter time than
+	   images are stored as coverage changes. Returns whether or not this storing was successful.
+	*/
+	SaveNoCoverage(ctx context.Context, imageUuid *ImageUuid) bool
+
+	/*
+	   HasNoCoverage retrieves whether it is known that there is no image for the location (or panorama) of the uuid.
+	*/
+	HasNoCoverage(ctx context.Context, imageUuid *ImageUuid) bool
 }
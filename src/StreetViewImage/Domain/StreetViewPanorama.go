@@ -0,0 +1,69 @@
+package Domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+)
+
+/* panoramaJpegQuality is the quality the stitched panorama is encoded with, the slices are already lossy. */
+const panoramaJpegQuality = 90
+
+/*
+NewStreetViewPanorama stitches the slices, images taken at evenly spaced headings from the same location by the
+provider, side by side into a single wide image (left to right in the order provided) and returns it as a
+StreetViewImage identified by the given panorama uuid. An error is returned if any of the slices is invalid or they are
+not all the same size.
+*/
+func NewStreetViewPanorama(uuid *ImageUuid, slices [][]byte, provider string) (StreetViewImage, error) {
+	if len(slices) == 0 {
+		return nil, errors.New("a panorama requires at least one slice to be stitched")
+	}
+
+	decodedSlices := make([]image.Image, len(slices))
+
+	for index, slice := range slices {
+		if err := validateImage(slice); err != nil {
+			return nil, err
+		}
+
+		decodedSlice, err := jpeg.Decode(bytes.NewReader(slice))
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to decode panorama slice %d, error: '%s'", index, err.Error()))
+		}
+
+		decodedSlices[index] = decodedSlice
+	}
+
+	sliceBounds := decodedSlices[0].Bounds()
+	panorama := image.NewRGBA(image.Rect(0, 0, sliceBounds.Dx()*len(decodedSlices), sliceBounds.Dy()))
+
+	for index, decodedSlice := range decodedSlices {
+		bounds := decodedSlice.Bounds()
+
+		if bounds.Dx() != sliceBounds.Dx() || bounds.Dy() != sliceBounds.Dy() {
+			return nil, errors.New(
+				fmt.Sprintf(
+					"panorama slice %d is %dx%d, expected all slices to be %dx%d",
+					index, bounds.Dx(), bounds.Dy(), sliceBounds.Dx(), sliceBounds.Dy(),
+				),
+			)
+		}
+
+		offset := image.Rect(index*bounds.Dx(), 0, (index+1)*bounds.Dx(), bounds.Dy())
+
+		draw.Draw(panorama, offset, decodedSlice, bounds.Min, draw.Src)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := jpeg.Encode(&buffer, panorama, &jpeg.Options{Quality: panoramaJpegQuality}); err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to encode stitched panorama, error: '%s'", err.Error()))
+	}
+
+	return NewStreetViewImageFromUuid(uuid, buffer.Bytes(), provider)
+}
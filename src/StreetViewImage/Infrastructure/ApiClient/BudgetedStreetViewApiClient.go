@@ -0,0 +1,74 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Domain"
+	"context"
+)
+
+/*
+budgetedStreetViewApiClient decorates a StreetViewApiClient with the monthly budget, reserving every image request from
+the budget before it is made and refusing to make any more once the budget is spent. Images already in the cache are
+still served as normal as they never reach the api client, and metadata requests are free so never refused.
+*/
+type budgetedStreetViewApiClient struct {
+	client StreetViewApiClient
+	budget StreetViewApiBudget
+}
+
+/* newBudgetedStreetViewApiClient returns a new StreetViewApiClient decorated with the budget. */
+func newBudgetedStreetViewApiClient(client StreetViewApiClient, budget StreetViewApiBudget) StreetViewApiClient {
+	return &budgetedStreetViewApiClient{client: client, budget: budget}
+}
+
+/* GetProvider retrieves the name of the imagery provider the decorated client retrieves images from. */
+func (c *budgetedStreetViewApiClient) GetProvider() string {
+	return c.client.GetProvider()
+}
+
+/* Request performs a request to the street view api with the runtime provided latitude, longitude and parameters. */
+func (c *budgetedStreetViewApiClient) Request(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	reservation, err := c.budget.Reserve(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := c.client.Request(ctx, latitude, longitude, parameters)
+
+	c.settle(reservation, err)
+
+	return image, err
+}
+
+/* RequestByPanoId performs a request to the street view api for a specific panorama and the parameters. */
+func (c *budgetedStreetViewApiClient) RequestByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	reservation, err := c.budget.Reserve(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := c.client.RequestByPanoId(ctx, panoId, parameters)
+
+	c.settle(reservation, err)
+
+	return image, err
+}
+
+/* RequestMetadata performs a request to the (free) metadata endpoint for the location and parameters. */
+func (c *budgetedStreetViewApiClient) RequestMetadata(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewMetadata, error) {
+	return c.client.RequestMetadata(ctx, latitude, longitude, parameters)
+}
+
+/* settle releases the reservation unless the image request it was made for succeeded, as only images are billed. */
+func (c *budgetedStreetViewApiClient) settle(reservation StreetViewApiReservation, err error) {
+	if err != nil {
+		c.budget.Release(reservation)
+	}
+}
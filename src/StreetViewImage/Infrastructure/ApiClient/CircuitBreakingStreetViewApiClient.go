@@ -0,0 +1,103 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"context"
+	"fmt"
+)
+
+/*
+circuitBreakingStreetViewApiClient decorates a StreetViewApiClient with a circuit breaker, so that whilst the api is
+down calls fail fast with an ApplicationError instead of being made (and retried) at all. Images already in the cache
+are still served as normal as they never reach the api client.
+*/
+type circuitBreakingStreetViewApiClient struct {
+	client  StreetViewApiClient
+	breaker StreetViewApiCircuitBreaker
+}
+
+/* newCircuitBreakingStreetViewApiClient returns a new StreetViewApiClient decorated with the circuit breaker. */
+func newCircuitBreakingStreetViewApiClient(
+	client StreetViewApiClient, breaker StreetViewApiCircuitBreaker,
+) StreetViewApiClient {
+	return &circuitBreakingStreetViewApiClient{client: client, breaker: breaker}
+}
+
+/* GetProvider retrieves the name of the imagery provider the decorated client retrieves images from. */
+func (c *circuitBreakingStreetViewApiClient) GetProvider() string {
+	return c.client.GetProvider()
+}
+
+/* Request performs a request to the street view api with the runtime provided latitude, longitude and parameters. */
+func (c *circuitBreakingStreetViewApiClient) Request(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	var image *StreetViewApiImage
+
+	err := c.call(func() (err error) {
+		image, err = c.client.Request(ctx, latitude, longitude, parameters)
+
+		return err
+	})
+
+	return image, err
+}
+
+/* RequestByPanoId performs a request to the street view api for a specific panorama and the parameters. */
+func (c *circuitBreakingStreetViewApiClient) RequestByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	var image *StreetViewApiImage
+
+	err := c.call(func() (err error) {
+		image, err = c.client.RequestByPanoId(ctx, panoId, parameters)
+
+		return err
+	})
+
+	return image, err
+}
+
+/* RequestMetadata performs a request to the (free) metadata endpoint for the location and parameters. */
+func (c *circuitBreakingStreetViewApiClient) RequestMetadata(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewMetadata, error) {
+	var metadata *StreetViewMetadata
+
+	err := c.call(func() (err error) {
+		metadata, err = c.client.RequestMetadata(ctx, latitude, longitude, parameters)
+
+		return err
+	})
+
+	return metadata, err
+}
+
+/*
+call makes the call to the decorated client if the breaker allows it, and records it's outcome. A panicking call is
+recorded as a failure before the panic carries on, as a half-open breaker would otherwise wait on it's trial forever.
+*/
+func (c *circuitBreakingStreetViewApiClient) call(call func() error) error {
+	provider := c.client.GetProvider()
+
+	if err := c.breaker.Allow(provider); err != nil {
+		return err
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			c.breaker.RecordOutcome(provider, Error.NewApplicationError(
+				fmt.Sprintf("The call to the api of provider: '%s' panicked: '%v'", provider, recovered),
+			))
+
+			panic(recovered)
+		}
+	}()
+
+	err := call()
+
+	c.breaker.RecordOutcome(provider, err)
+
+	return err
+}
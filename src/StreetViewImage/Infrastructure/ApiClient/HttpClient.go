@@ -0,0 +1,101 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+/*
+HttpClient performs the http requests made to the imagery providers' apis.
+*/
+type HttpClient interface {
+	/* Do sends the request and returns the response, see: http.Client. */
+	Do(request *http.Request) (*http.Response, error)
+}
+
+/*
+NewHttpClient returns a new HttpClient with a connection pool and the configured timeouts, sending requests through the
+configured proxy (or the one in the environment) and trusting the CA bundle on top of the system's CAs.
+
+An invalid proxy url or CA bundle is logged and ignored, as the requests may well still succeed without them.
+*/
+func NewHttpClient(config config.HttpClientConfiguration, logger *Logger.LoggingStrategy) HttpClient {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   time.Duration(config.GetDialTimeout()) * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   time.Duration(config.GetTlsHandshakeTimeout()) * time.Second,
+		ResponseHeaderTimeout: time.Duration(config.GetResponseHeaderTimeout()) * time.Second,
+		MaxIdleConns:          config.GetMaxIdleConnections() * 2,
+		MaxIdleConnsPerHost:   config.GetMaxIdleConnections(),
+		IdleConnTimeout:       time.Duration(config.GetIdleConnectionTimeout()) * time.Second,
+		/* A custom dialer or TLS config (for the CA bundle) would otherwise leave the transport on HTTP/1.1. */
+		ForceAttemptHTTP2: true,
+	}
+
+	if config.GetProxyUrl() != "" {
+		proxyUrl, err := url.Parse(config.GetProxyUrl())
+
+		if err != nil {
+			logger.Error(fmt.Sprintf("Invalid http client proxy url configured, ignoring it, error: '%s'", err.Error()))
+		} else {
+			transport.Proxy = http.ProxyURL(proxyUrl)
+		}
+	}
+
+	if config.GetCaBundle() != "" {
+		rootCAs, err := loadCaBundle(config.GetCaBundle())
+
+		if err != nil {
+			logger.Error(fmt.Sprintf("Unable to load http client CA bundle, ignoring it, error: '%s'", err.Error()))
+		} else {
+			transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+		}
+	}
+
+	return &http.Client{Transport: transport, Timeout: time.Duration(config.GetTimeout()) * time.Second}
+}
+
+/* loadCaBundle loads the PEM encoded certificates at the path into a pool, along with the system's certificates. */
+func loadCaBundle(path string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New(fmt.Sprintf("no certificates found in: '%s'", path))
+	}
+
+	return pool, nil
+}
+
+/* get performs a GET request with the client, that is aborted once the context is done. */
+func get(ctx context.Context, client HttpClient, uri string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Do(request)
+}
@@ -0,0 +1,74 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+/* newTestTlsServer returns a TLS server speaking HTTP/2, along with the path of a CA bundle of it's certificate. */
+func newTestTlsServer(t *testing.T) (*httptest.Server, string) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+
+	t.Cleanup(server.Close)
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	if err := ioutil.WriteFile(caBundle, certificate, 0600); err != nil {
+		t.Fatalf("Unable to write the CA bundle, error: '%s'", err.Error())
+	}
+
+	return server, caBundle
+}
+
+/* newTestHttpClient returns a HttpClient configured with the CA bundle. */
+func newTestHttpClient(t *testing.T, caBundle string) HttpClient {
+	httpConfig := config.HttpClientConfiguration{}
+
+	parseTestConfiguration(t, &httpConfig, map[string]string{"HTTP_CLIENT_CA_BUNDLE": caBundle})
+
+	return NewHttpClient(httpConfig, &Logger.LoggingStrategy{})
+}
+
+func TestHttpClientTrustsTheCaBundleOverHttp2(t *testing.T) {
+	server, caBundle := newTestTlsServer(t)
+
+	response, err := get(context.Background(), newTestHttpClient(t, caBundle), server.URL)
+
+	if err != nil {
+		t.Fatalf("Expected the CA bundle to be trusted, got error: '%s'", err.Error())
+	}
+
+	defer func() { _ = response.Body.Close() }()
+
+	/* The CA bundle's TLS config must not leave the transport on HTTP/1.1. */
+	if response.ProtoMajor != 2 {
+		t.Errorf("Expected the request to be made over HTTP/2, got: '%s'", response.Proto)
+	}
+}
+
+func TestHttpClientIgnoresAnInvalidCaBundle(t *testing.T) {
+	server, _ := newTestTlsServer(t)
+
+	invalidBundle := filepath.Join(t.TempDir(), "invalid.pem")
+
+	if err := ioutil.WriteFile(invalidBundle, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("Unable to write the CA bundle, error: '%s'", err.Error())
+	}
+
+	/* Only the system's CAs are trusted, which the test server's certificate is not signed by. */
+	if _, err := get(context.Background(), newTestHttpClient(t, invalidBundle), server.URL); err == nil {
+		t.Error("Expected the test server's certificate not to be trusted, got a response")
+	}
+}
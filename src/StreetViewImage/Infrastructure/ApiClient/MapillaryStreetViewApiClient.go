@@ -0,0 +1,410 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	/* MapillaryProvider is the name of the Mapillary imagery provider, as configured and recorded in the cache key. */
+	MapillaryProvider = "mapillary"
+
+	/* mapillaryImageFields are the fields of an image requested from the graph api, the thumbnails being the images. */
+	mapillaryImageFields = "id,captured_at,geometry,thumb_256_url,thumb_1024_url,thumb_2048_url"
+
+	/* mapillaryCopyright is the attribution that must be displayed with the image, as there's none per image. */
+	mapillaryCopyright = "© Mapillary"
+
+	/*
+		mapillarySearchLimit is the most images a search returns. The graph api returns the images in a bounding box in
+		no particular order, so enough are requested for the nearest to be among them.
+	*/
+	mapillarySearchLimit = 100
+
+	/* metresPerDegree is the length of a degree of latitude, and of longitude at the equator, in metres. */
+	metresPerDegree = 111320.0
+
+	/* earthRadius is the mean radius of the earth in metres, for the distance between two locations. */
+	earthRadius = 6371000.0
+)
+
+/*
+mapillaryImage is an image as returned by the graph api, see: https://www.mapillary.com/developer/api-documentation.
+*/
+type mapillaryImage struct {
+	Id string `json:"id"`
+	/* CapturedAt is the time the image was captured, in milliseconds since the epoch. */
+	CapturedAt int64 `json:"captured_at"`
+	/* Geometry is a GeoJSON point, the coordinates of which are: longitude, latitude. */
+	Geometry struct {
+		Coordinates []float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Thumb256Url  string `json:"thumb_256_url"`
+	Thumb1024Url string `json:"thumb_1024_url"`
+	Thumb2048Url string `json:"thumb_2048_url"`
+}
+
+/* mapillaryImages is the response of an image search, the images found being in no particular order. */
+type mapillaryImages struct {
+	Data []mapillaryImage `json:"data"`
+}
+
+/*
+mapillaryStreetViewApiClient retrieves images from Mapillary's graph api: the nearest image to a location is searched
+for and it's thumbnail downloaded. A pano id is the id of a Mapillary image.
+
+Mapillary images are photos as they were taken, so they can not be framed with a heading, pitch or fov, and come in the
+thumbnail width nearest the requested width.
+*/
+type mapillaryStreetViewApiClient struct {
+	config    *config.MapillaryConfiguration
+	apiConfig *config.StreetViewApiConfiguration
+	logger    Logger.LoggingStrategy
+
+	/* retrierFactory creates a retrier per request, as a retrier keeps state and requests can run concurrently. */
+	retrierFactory RetrierFactory
+
+	/* httpClient is shared by every api client, so that connections are pooled. */
+	httpClient HttpClient
+
+	/* status records the outcome of every call, for health checking. */
+	status StreetViewApiStatus
+}
+
+/* newMapillaryStreetViewApiClient returns a new StreetViewApiClient retrieving images from Mapillary. */
+func newMapillaryStreetViewApiClient(
+	config *config.MapillaryConfiguration,
+	apiConfig *config.StreetViewApiConfiguration,
+	retrierFactory RetrierFactory,
+	httpClient HttpClient,
+	logger Logger.LoggingStrategy,
+	status StreetViewApiStatus,
+) StreetViewApiClient {
+	return &mapillaryStreetViewApiClient{
+		config:         config,
+		apiConfig:      apiConfig,
+		retrierFactory: retrierFactory,
+		httpClient:     httpClient,
+		logger:         logger,
+		status:         status,
+	}
+}
+
+/* GetProvider retrieves the name of the imagery provider the client retrieves images from. */
+func (c *mapillaryStreetViewApiClient) GetProvider() string {
+	return MapillaryProvider
+}
+
+/* Request retrieves the thumbnail of the image nearest to the latitude and longitude. */
+func (c *mapillaryStreetViewApiClient) Request(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	image, err := c.findNearestImage(ctx, latitude, longitude, parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if image == nil {
+		return nil, Error.UserError{Code: InvalidLocationCode, Err: InvalidLocationCodeErr}
+	}
+
+	return c.requestThumbnail(ctx, image, parameters)
+}
+
+/* RequestByPanoId retrieves the thumbnail of the image with the id. */
+func (c *mapillaryStreetViewApiClient) RequestByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	image := &mapillaryImage{}
+
+	/* The graph api responds to an unknown (or invalid) id with a bad request rather than a not found. */
+	found, err := c.requestJson(
+		ctx, c.buildUrl(url.PathEscape(panoId), url.Values{}), image, http.StatusNotFound, http.StatusBadRequest,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, Error.UserError{Code: InvalidPanoIdCode, Err: InvalidPanoIdErr}
+	}
+
+	return c.requestThumbnail(ctx, image, parameters)
+}
+
+/* RequestMetadata describes the image nearest to the latitude and longitude, in the shape of Google's metadata. */
+func (c *mapillaryStreetViewApiClient) RequestMetadata(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewMetadata, error) {
+	image, err := c.findNearestImage(ctx, latitude, longitude, parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if image == nil {
+		return &StreetViewMetadata{Status: MetadataStatusZeroResults, Provider: MapillaryProvider}, nil
+	}
+
+	metadata := &StreetViewMetadata{
+		Status:    MetadataStatusOk,
+		PanoId:    image.Id,
+		Date:      time.Unix(0, image.CapturedAt*int64(time.Millisecond)).UTC().Format("2006-01"),
+		Copyright: mapillaryCopyright,
+		Provider:  MapillaryProvider,
+	}
+
+	if len(image.Geometry.Coordinates) == 2 {
+		metadata.Location = MetadataLocation{
+			Latitude: image.Geometry.Coordinates[1], Longitude: image.Geometry.Coordinates[0],
+		}
+	}
+
+	return metadata, nil
+}
+
+/*
+findNearestImage searches for the image nearest to the location within the radius, nil when there is none. The graph
+api can only search a bounding box, so the box around the radius is searched and the nearest image within the radius
+chosen from those found.
+
+See: https://www.mapillary.com/developer/api-documentation#image.
+*/
+func (c *mapillaryStreetViewApiClient) findNearestImage(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*mapillaryImage, error) {
+	radius := c.config.GetSearchRadius()
+
+	if parameters.GetRadius() != nil {
+		radius = *parameters.GetRadius()
+	}
+
+	query := url.Values{}
+	query.Set("bbox", buildBoundingBox(latitude, longitude, float64(radius)))
+	query.Set("limit", strconv.Itoa(mapillarySearchLimit))
+
+	images := &mapillaryImages{}
+
+	/*
+		A search finds nothing with an empty list, so any other response is a failure of the api, or of the access token,
+		rather than there being no image.
+	*/
+	if _, err := c.requestJson(ctx, c.buildUrl("images", query), images); err != nil {
+		return nil, err
+	}
+
+	var nearest *mapillaryImage
+
+	nearestDistance := float64(radius)
+
+	for index, image := range images.Data {
+		if len(image.Geometry.Coordinates) != 2 {
+			continue
+		}
+
+		distance := calculateDistance(latitude, longitude, image.Geometry.Coordinates[1], image.Geometry.Coordinates[0])
+
+		if distance <= nearestDistance {
+			nearest, nearestDistance = &images.Data[index], distance
+		}
+	}
+
+	return nearest, nil
+}
+
+/* requestThumbnail downloads the thumbnail of the image nearest the requested (or configured) width. */
+func (c *mapillaryStreetViewApiClient) requestThumbnail(
+	ctx context.Context, image *mapillaryImage, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	width := c.apiConfig.GetWidth()
+
+	if parameters.GetWidth() != nil {
+		width = *parameters.GetWidth()
+	}
+
+	thumbnailUrl := image.Thumb2048Url
+
+	if width <= 256 && image.Thumb256Url != "" {
+		thumbnailUrl = image.Thumb256Url
+	} else if width <= 1024 && image.Thumb1024Url != "" {
+		thumbnailUrl = image.Thumb1024Url
+	}
+
+	if thumbnailUrl == "" {
+		return nil, Error.NewApplicationError(fmt.Sprintf("Mapillary image: '%s' has no thumbnail", image.Id))
+	}
+
+	res, err := c.request(ctx, thumbnailUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Mapillary thumbnail of image: '%s' responded with status: '%d'", image.Id, res.StatusCode),
+		)
+	}
+
+	imageBytes, err := ioutil.ReadAll(res.Body)
+
+	if err != nil {
+		/* A thumbnail cut short by the caller giving up is not the api failing. */
+		if ctxErr := NewRequestContextError(ctx); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Unable to read Mapillary thumbnail of image: '%s', error: '%s'", image.Id, err.Error()),
+		)
+	}
+
+	return &StreetViewApiImage{Bytes: imageBytes, Provider: MapillaryProvider}, nil
+}
+
+/*
+requestJson performs a request to the graph api and decodes the json response into the target, returning false when the
+graph api responds with one of the not found statuses, which the caller gives as they differ per request. Any other
+status is an ApplicationError, recorded as the outcome of the call.
+*/
+func (c *mapillaryStreetViewApiClient) requestJson(
+	ctx context.Context, uri string, target interface{}, notFoundStatuses ...int,
+) (bool, error) {
+	res, err := c.request(ctx, uri)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	for _, notFoundStatus := range notFoundStatuses {
+		if res.StatusCode == notFoundStatus {
+			return false, nil
+		}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		err := Error.NewApplicationError(
+			fmt.Sprintf("Mapillary request to: '%s' responded with status: '%d'", redactUrl(uri), res.StatusCode),
+		)
+
+		c.status.RecordOutcome(MapillaryProvider, err)
+
+		return false, err
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(target); err != nil {
+		return false, Error.NewApplicationError(
+			fmt.Sprintf("Unable to parse Mapillary response from: '%s', error: '%s'", redactUrl(uri), err.Error()),
+		)
+	}
+
+	return true, nil
+}
+
+/*
+request performs a GET request, retrying on network errors, server errors and too many requests. Any other response is
+returned for the caller to interpret.
+*/
+func (c *mapillaryStreetViewApiClient) request(ctx context.Context, uri string) (*http.Response, error) {
+	uriStringForLogging := redactUrl(uri)
+
+	c.logger.Debug(fmt.Sprintf("Making request to: %s", uriStringForLogging))
+
+	var res *http.Response
+
+	retryErr := c.retrierFactory.Create(ctx, c.apiConfig).ExecuteFuncWithRetry(func() error {
+		response, err := get(ctx, c.httpClient, uri)
+
+		if err != nil {
+			return err
+		}
+
+		if isRetryableStatus(response.StatusCode) {
+			return newResponseError(response)
+		}
+
+		res = response
+
+		return nil
+	})
+
+	if retryErr != nil {
+		/* The caller giving up says nothing about the health of the api, so it's outcome is not recorded. */
+		if err := NewRequestContextError(ctx); err != nil {
+			return nil, err
+		}
+
+		err := Error.NewApplicationError(
+			fmt.Sprintf("Error making request to: '%s', %s", uriStringForLogging, retryErr.Error()),
+		)
+
+		c.status.RecordOutcome(MapillaryProvider, err)
+
+		return nil, err
+	}
+
+	c.status.RecordOutcome(MapillaryProvider, nil)
+
+	return res, nil
+}
+
+/* buildUrl builds the full url of a graph api request from the configured endpoint, the path and the query. */
+func (c *mapillaryStreetViewApiClient) buildUrl(path string, query url.Values) string {
+	query.Set("access_token", c.config.GetAccessToken())
+	query.Set("fields", mapillaryImageFields)
+
+	return fmt.Sprintf("%s/%s?%s", strings.TrimRight(c.config.GetEndpoint(), "/"), path, query.Encode())
+}
+
+/*
+buildBoundingBox builds the bbox GET parameter of the square around the location that the radius fits in: the min
+longitude, min latitude, max longitude and max latitude. A degree of longitude gets shorter away from the equator, so
+the box gets wider to fit the radius, up to every longitude near the poles.
+*/
+func buildBoundingBox(latitude float64, longitude float64, radius float64) string {
+	latitudeDelta := radius / metresPerDegree
+	longitudeDelta := 180.0
+
+	if cos := math.Cos(latitude * math.Pi / 180); cos > radius/(metresPerDegree*180) {
+		longitudeDelta = math.Min(180, radius/(metresPerDegree*cos))
+	}
+
+	return fmt.Sprintf(
+		"%f,%f,%f,%f",
+		math.Max(-180, longitude-longitudeDelta),
+		math.Max(-90, latitude-latitudeDelta),
+		math.Min(180, longitude+longitudeDelta),
+		math.Min(90, latitude+latitudeDelta),
+	)
+}
+
+/* calculateDistance calculates the distance in metres between two locations, with the haversine formula. */
+func calculateDistance(fromLatitude float64, fromLongitude float64, toLatitude float64, toLongitude float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	latitudeDelta := toRadians(toLatitude - fromLatitude)
+	longitudeDelta := toRadians(toLongitude - fromLongitude)
+
+	a := math.Pow(math.Sin(latitudeDelta/2), 2) +
+		math.Cos(toRadians(fromLatitude))*math.Cos(toRadians(toLatitude))*math.Pow(math.Sin(longitudeDelta/2), 2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,475 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/j7mbo/goenvconfig"
+)
+
+const (
+	/* testMapillaryAccessToken is the access token the fake graph api expects, and that must never be logged. */
+	testMapillaryAccessToken = "MLY|test-access-token"
+
+	/* testMapillaryExpiredAccessToken is an access token the fake graph api responds to as expired. */
+	testMapillaryExpiredAccessToken = "MLY|expired-access-token"
+
+	/* testMapillaryImageId is the id of the image nearest to a latitude of 51.5 and a longitude of -0.1, ~13m away. */
+	testMapillaryImageId = "1234567890"
+
+	/* testMapillaryFurtherImageId is the id of an image further from the same location, ~39m away. */
+	testMapillaryFurtherImageId = "2345678901"
+
+	/* testMapillaryOutsideImageId is the id of an image in the bounding box of a 50m radius, but ~61m away. */
+	testMapillaryOutsideImageId = "3456789012"
+)
+
+/*
+fakeMapillary is a fake of the graph api, with three images around a latitude of 51.5 and a longitude of -0.1 each
+with three thumbnails. Like the graph api an image search must be within a bbox and returns the images in it in no
+particular order, any other search being responded to with a bad request. Unknown image ids are responded to with a bad
+request (as the graph api does) or not found, for the ids "bad" and "missing" respectively. An expired access token is
+responded to with the bad request of an OAuthException, as the graph api does, and any other with unauthorized. Every
+query the images search was made with is recorded.
+*/
+type fakeMapillary struct {
+	mutex    sync.Mutex
+	server   *httptest.Server
+	searches []url.Values
+}
+
+/* newFakeMapillary starts a new fakeMapillary, closed once the test has run. */
+func newFakeMapillary(t *testing.T) *fakeMapillary {
+	fake := &fakeMapillary{}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+/* getSearches retrieves the queries of the image searches made so far. */
+func (f *fakeMapillary) getSearches() []url.Values {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]url.Values{}, f.searches...)
+}
+
+func (f *fakeMapillary) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if strings.HasPrefix(r.URL.Path, "/thumbnails/") {
+		_, _ = w.Write([]byte(strings.TrimPrefix(r.URL.Path, "/thumbnails/")))
+
+		return
+	}
+
+	if query.Get("access_token") == testMapillaryExpiredAccessToken {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Error validating access token","type":"OAuthException","code":190}}`))
+
+		return
+	}
+
+	if query.Get("access_token") != testMapillaryAccessToken {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	switch r.URL.Path {
+	case "/images":
+		f.mutex.Lock()
+		f.searches = append(f.searches, query)
+		f.mutex.Unlock()
+
+		images, err := f.searchImages(query)
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(images)
+	case "/" + testMapillaryImageId:
+		_ = json.NewEncoder(w).Encode(f.images()[1])
+	case "/missing":
+		w.WriteHeader(http.StatusNotFound)
+	case "/broken":
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+/* images returns every image, the thumbnails of which respond with their size. The nearest is not the first. */
+func (f *fakeMapillary) images() []mapillaryImage {
+	locations := []struct {
+		id        string
+		latitude  float64
+		longitude float64
+	}{
+		{id: testMapillaryFurtherImageId, latitude: 51.5003, longitude: -0.1003},
+		{id: testMapillaryImageId, latitude: 51.5001, longitude: -0.1001},
+		{id: testMapillaryOutsideImageId, latitude: 51.5004, longitude: -0.1006},
+	}
+
+	images := make([]mapillaryImage, len(locations))
+
+	for index, location := range locations {
+		images[index] = mapillaryImage{
+			Id:           location.id,
+			CapturedAt:   1546300800000,
+			Thumb256Url:  f.server.URL + "/thumbnails/256",
+			Thumb1024Url: f.server.URL + "/thumbnails/1024",
+			Thumb2048Url: f.server.URL + "/thumbnails/2048",
+		}
+		images[index].Geometry.Coordinates = []float64{location.longitude, location.latitude}
+	}
+
+	return images
+}
+
+/*
+searchImages returns the images within the bbox of the search, which is: min longitude, min latitude, max longitude and
+max latitude. Parameters the graph api does not have, such as the v3 closeto and radius, fail the search.
+*/
+func (f *fakeMapillary) searchImages(query url.Values) (mapillaryImages, error) {
+	images := mapillaryImages{Data: []mapillaryImage{}}
+
+	for name := range query {
+		if name != "access_token" && name != "fields" && name != "bbox" && name != "limit" {
+			return images, fmt.Errorf("unknown parameter: '%s'", name)
+		}
+	}
+
+	if limit, err := strconv.Atoi(query.Get("limit")); query.Get("limit") != "" && (err != nil || limit > 2000) {
+		return images, fmt.Errorf("invalid limit: '%s'", query.Get("limit"))
+	}
+
+	var bbox [4]float64
+
+	bounds := strings.Split(query.Get("bbox"), ",")
+
+	if len(bounds) != len(bbox) {
+		return images, fmt.Errorf("invalid bbox: '%s'", query.Get("bbox"))
+	}
+
+	for index, bound := range bounds {
+		var err error
+
+		if bbox[index], err = strconv.ParseFloat(bound, 64); err != nil {
+			return images, fmt.Errorf("invalid bbox: '%s'", query.Get("bbox"))
+		}
+	}
+
+	for _, image := range f.images() {
+		longitude, latitude := image.Geometry.Coordinates[0], image.Geometry.Coordinates[1]
+
+		if longitude >= bbox[0] && latitude >= bbox[1] && longitude <= bbox[2] && latitude <= bbox[3] {
+			images.Data = append(images.Data, image)
+		}
+	}
+
+	return images, nil
+}
+
+/* parseTestConfiguration parses a configuration from the environment, with the variables given set for the test. */
+func parseTestConfiguration(t *testing.T, configuration interface{}, variables map[string]string) {
+	for name, value := range variables {
+		t.Setenv(name, value)
+	}
+
+	if err := goenvconfig.NewGoEnvParser().Parse(configuration); err != nil {
+		t.Fatalf("Unable to parse the test configuration, error: '%s'", err.Error())
+	}
+}
+
+/* newTestApiConfiguration returns an api configuration that retries immediately, so that tests do not wait. */
+func newTestApiConfiguration(t *testing.T, variables map[string]string) *config.StreetViewApiConfiguration {
+	apiConfig := config.StreetViewApiConfiguration{}
+
+	variables["STREETVIEW_API_MAX_RETRIES"] = "3"
+	variables["STREETVIEW_API_RETRY_DELAY"] = "0"
+
+	parseTestConfiguration(t, &apiConfig, variables)
+
+	return &apiConfig
+}
+
+/* newTestMapillaryClient returns a Mapillary client retrieving images from the fake graph api. */
+func newTestMapillaryClient(t *testing.T, fake *fakeMapillary) StreetViewApiClient {
+	return newTestMapillaryClientWithAccessToken(t, fake, testMapillaryAccessToken)
+}
+
+/* newTestMapillaryClientWithAccessToken returns a Mapillary client calling the fake graph api with the access token. */
+func newTestMapillaryClientWithAccessToken(
+	t *testing.T, fake *fakeMapillary, accessToken string,
+) *mapillaryStreetViewApiClient {
+	mapillaryConfig := config.MapillaryConfiguration{}
+
+	parseTestConfiguration(t, &mapillaryConfig, map[string]string{
+		"MAPILLARY_API_ENDPOINT": fake.server.URL, "MAPILLARY_ACCESS_TOKEN": accessToken,
+	})
+
+	return newMapillaryStreetViewApiClient(
+		&mapillaryConfig,
+		newTestApiConfiguration(t, map[string]string{}),
+		RetrierFactory{},
+		fake.server.Client(),
+		Logger.LoggingStrategy{},
+		NewStreetViewApiStatus(),
+	).(*mapillaryStreetViewApiClient)
+}
+
+func TestMapillaryRequestRetrievesTheThumbnailOfTheNearestImage(t *testing.T) {
+	fake := newFakeMapillary(t)
+	client := newTestMapillaryClient(t, fake)
+
+	image, err := client.Request(context.Background(), 51.5, -0.1, Domain.NewImageParameters(nil, nil, nil, nil, ""))
+
+	if err != nil {
+		t.Fatalf("Expected the thumbnail, got error: '%s'", err.Error())
+	}
+
+	if image.Provider != MapillaryProvider {
+		t.Errorf("Expected provider: '%s', got: '%s'", MapillaryProvider, image.Provider)
+	}
+
+	searches := fake.getSearches()
+
+	if len(searches) != 1 {
+		t.Fatalf("Expected one image search, got: '%d'", len(searches))
+	}
+
+	/* A 50m radius is ~0.000449 degrees of latitude, and ~0.000722 degrees of longitude at a latitude of 51.5. */
+	expected := map[string]string{"bbox": "-0.100722,51.499551,-0.099278,51.500449", "limit": "100"}
+
+	for name, value := range expected {
+		if searches[0].Get(name) != value {
+			t.Errorf("Expected the search: '%s' to be: '%s', got: '%s'", name, value, searches[0].Get(name))
+		}
+	}
+}
+
+func TestMapillaryRequestSearchesWithinTheRequestedRadius(t *testing.T) {
+	fake := newFakeMapillary(t)
+	client := newTestMapillaryClient(t, fake)
+
+	tests := []struct {
+		radius   int
+		expected string
+	}{
+		/* Within 10m of the location there is no image. */
+		{radius: 10, expected: ""},
+		{radius: 20, expected: testMapillaryImageId},
+		/* The image in the corner of the bbox is only chosen once within the radius, and only when it is the nearest. */
+		{radius: 100, expected: testMapillaryImageId},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("radius %d", test.radius), func(t *testing.T) {
+			radius := test.radius
+
+			metadata, err := client.RequestMetadata(
+				context.Background(), 51.5, -0.1, Domain.NewImageParameters(nil, nil, nil, &radius, ""),
+			)
+
+			if err != nil {
+				t.Fatalf("Expected the metadata, got error: '%s'", err.Error())
+			}
+
+			if metadata.PanoId != test.expected {
+				t.Errorf("Expected the image: '%s', got: '%+v'", test.expected, metadata)
+			}
+		})
+	}
+}
+
+func TestMapillaryRequestChoosesTheNearestImageWithinTheRadius(t *testing.T) {
+	client := newTestMapillaryClient(t, newFakeMapillary(t))
+	parameters := Domain.NewImageParameters(nil, nil, nil, nil, "")
+
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		expected  string
+	}{
+		{name: "nearest returned second", latitude: 51.5, longitude: -0.1, expected: testMapillaryImageId},
+		{name: "nearest returned first", latitude: 51.5003, longitude: -0.1003, expected: testMapillaryFurtherImageId},
+		{name: "nearest returned last", latitude: 51.5004, longitude: -0.1007, expected: testMapillaryOutsideImageId},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata, err := client.RequestMetadata(context.Background(), test.latitude, test.longitude, parameters)
+
+			if err != nil {
+				t.Fatalf("Expected the metadata, got error: '%s'", err.Error())
+			}
+
+			if metadata.PanoId != test.expected {
+				t.Errorf("Expected the image: '%s', got: '%+v'", test.expected, metadata)
+			}
+		})
+	}
+}
+
+func TestMapillaryRequestMetadataDescribesTheNearestImage(t *testing.T) {
+	client := newTestMapillaryClient(t, newFakeMapillary(t))
+
+	metadata, err := client.RequestMetadata(
+		context.Background(), 51.5, -0.1, Domain.NewImageParameters(nil, nil, nil, nil, ""),
+	)
+
+	if err != nil {
+		t.Fatalf("Expected the metadata, got error: '%s'", err.Error())
+	}
+
+	if metadata.Status != MetadataStatusOk || metadata.PanoId != testMapillaryImageId || metadata.Date != "2019-01" {
+		t.Errorf("Expected the metadata of image: '%s', got: '%+v'", testMapillaryImageId, metadata)
+	}
+
+	if metadata.Location.Latitude != 51.5001 || metadata.Location.Longitude != -0.1001 {
+		t.Errorf("Expected the location of the image, got: '%+v'", metadata.Location)
+	}
+}
+
+func TestMapillaryRequestChoosesTheThumbnailNearestTheWidth(t *testing.T) {
+	client := newTestMapillaryClient(t, newFakeMapillary(t))
+
+	tests := []struct {
+		width    int
+		expected string
+	}{
+		{width: 0, expected: "1024"},
+		{width: 200, expected: "256"},
+		{width: 256, expected: "256"},
+		{width: 640, expected: "1024"},
+		{width: 1024, expected: "1024"},
+		{width: 1500, expected: "2048"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("width %d", test.width), func(t *testing.T) {
+			parameters := Domain.NewImageParameters(nil, nil, nil, nil, "")
+
+			/* No width falls back to the configured width, which defaults to 400. */
+			if test.width != 0 {
+				parameters = parameters.WithSize(test.width, test.width)
+			}
+
+			image, err := client.RequestByPanoId(context.Background(), testMapillaryImageId, parameters)
+
+			if err != nil {
+				t.Fatalf("Expected the thumbnail, got error: '%s'", err.Error())
+			}
+
+			if string(image.Bytes) != test.expected {
+				t.Errorf("Expected the thumbnail: '%s', got: '%s'", test.expected, string(image.Bytes))
+			}
+		})
+	}
+}
+
+func TestMapillaryRequestMapsNoImageToNotFound(t *testing.T) {
+	client := newTestMapillaryClient(t, newFakeMapillary(t))
+	parameters := Domain.NewImageParameters(nil, nil, nil, nil, "")
+
+	_, err := client.Request(context.Background(), 10, 10, parameters)
+
+	if userErr, isUserErr := err.(Error.UserError); !isUserErr || userErr.Code != InvalidLocationCode {
+		t.Errorf("Expected a user error with code: '%s', got: '%v'", InvalidLocationCode, err)
+	}
+
+	metadata, err := client.RequestMetadata(context.Background(), 10, 10, parameters)
+
+	if err != nil || metadata.Status != MetadataStatusZeroResults {
+		t.Errorf("Expected metadata with status: '%s', got: '%+v', error: '%v'", MetadataStatusZeroResults, metadata, err)
+	}
+
+	/* The graph api responds to an unknown id with a bad request, and to one that no longer exists with not found. */
+	for _, panoId := range []string{"bad", "missing"} {
+		_, err := client.RequestByPanoId(context.Background(), panoId, parameters)
+
+		if userErr, isUserErr := err.(Error.UserError); !isUserErr || userErr.Code != InvalidPanoIdCode {
+			t.Errorf("Expected a user error with code: '%s' for: '%s', got: '%v'", InvalidPanoIdCode, panoId, err)
+		}
+	}
+}
+
+func TestMapillaryRequestFailsWhenTheSearchIsRefused(t *testing.T) {
+	parameters := Domain.NewImageParameters(nil, nil, nil, nil, "")
+
+	/* A refused access token is the api failing, which must not be mistaken for (and cached as) there being no image. */
+	for _, accessToken := range []string{testMapillaryExpiredAccessToken, "MLY|unknown-access-token"} {
+		t.Run(accessToken, func(t *testing.T) {
+			client := newTestMapillaryClientWithAccessToken(t, newFakeMapillary(t), accessToken)
+
+			_, err := client.Request(context.Background(), 51.5, -0.1, parameters)
+
+			if _, isApplicationErr := err.(Error.ApplicationError); !isApplicationErr {
+				t.Errorf("Expected an application error for the image, got: '%v'", err)
+			}
+
+			metadata, err := client.RequestMetadata(context.Background(), 51.5, -0.1, parameters)
+
+			if _, isApplicationErr := err.(Error.ApplicationError); !isApplicationErr {
+				t.Errorf("Expected an application error for the metadata, got: '%+v', error: '%v'", metadata, err)
+			}
+
+			if _, err := client.status.GetLastOutcome(MapillaryProvider); err == nil {
+				t.Error("Expected the failure to be recorded, got a success")
+			}
+
+			/* In a chain the status is shared with google, the health of which mapillary failing says nothing about. */
+			if at, _ := client.status.GetLastOutcome(GoogleProvider); !at.IsZero() {
+				t.Errorf("Expected no outcome to be recorded for google, got one at: '%s'", at)
+			}
+		})
+	}
+}
+
+func TestMapillaryRequestDoesNotExposeTheAccessToken(t *testing.T) {
+	client := newTestMapillaryClient(t, newFakeMapillary(t))
+
+	_, err := client.RequestByPanoId(context.Background(), "broken", Domain.NewImageParameters(nil, nil, nil, nil, ""))
+
+	if err == nil {
+		t.Fatal("Expected an error from the failing graph api, got none")
+	}
+
+	if strings.Contains(err.Error(), testMapillaryAccessToken) || strings.Contains(err.Error(), "test-access-token") {
+		t.Errorf("Expected the access token to be redacted, got: '%s'", err.Error())
+	}
+}
+
+func TestRedactUrlStripsTheAccessToken(t *testing.T) {
+	uri := "https://graph.mapillary.com/images?access_token=MLY%7Csecret&fields=id&limit=1"
+
+	redacted := redactUrl(uri)
+
+	if strings.Contains(redacted, "secret") {
+		t.Errorf("Expected the access token to be redacted, got: '%s'", redacted)
+	}
+
+	if !strings.Contains(redacted, "fields=id") || !strings.Contains(redacted, "limit=1") {
+		t.Errorf("Expected the rest of the query to be kept, got: '%s'", redacted)
+	}
+}
@@ -0,0 +1,190 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"fmt"
+	"strings"
+)
+
+/* providerSeparator separates the names of the imagery providers in a chain, both in config and the chain's name. */
+const providerSeparator = ","
+
+/*
+providerChainStreetViewApiClient tries the client of each imagery provider in turn and falls back to the next one when a
+provider has no image (ZERO_RESULTS) or fails, much like the LoggingStrategy falls back through loggers.
+
+The fallback ordering is in the same order of the passed in clients. A provider refusing the request because of how it
+is configured, such as it's api keys being over their quota, fails as much as one that is down. Any other UserError,
+such as the monthly budget being spent, is not the provider's answer and so is returned straight away.
+*/
+type providerChainStreetViewApiClient struct {
+	clients []StreetViewApiClient
+	logger  Logger.LoggingStrategy
+}
+
+/* newProviderChainStreetViewApiClient returns a new StreetViewApiClient falling back through the clients in order. */
+func newProviderChainStreetViewApiClient(
+	clients []StreetViewApiClient, logger Logger.LoggingStrategy,
+) StreetViewApiClient {
+	return &providerChainStreetViewApiClient{clients: clients, logger: logger}
+}
+
+/*
+GetProvider retrieves the names of the imagery providers in the chain, in order, so that images retrieved through
+differently ordered chains are never mixed up. Which provider an image was actually retrieved from is recorded with it.
+*/
+func (c *providerChainStreetViewApiClient) GetProvider() string {
+	providers := make([]string, len(c.clients))
+
+	for index, client := range c.clients {
+		providers[index] = client.GetProvider()
+	}
+
+	return strings.Join(providers, providerSeparator)
+}
+
+/* Request requests the image of the location from each provider in turn, until one of them has it. */
+func (c *providerChainStreetViewApiClient) Request(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	return c.requestImage(func(client StreetViewApiClient) (*StreetViewApiImage, error) {
+		return client.Request(ctx, latitude, longitude, parameters)
+	})
+}
+
+/*
+RequestByPanoId requests the image of the panorama from each provider in turn, until one of them has it. The pano ids of
+different providers do not look alike, so one provider's pano id is simply not found by the others.
+*/
+func (c *providerChainStreetViewApiClient) RequestByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	return c.requestImage(func(client StreetViewApiClient) (*StreetViewApiImage, error) {
+		return client.RequestByPanoId(ctx, panoId, parameters)
+	})
+}
+
+/*
+RequestMetadata requests the metadata of the location from each provider in turn, until one of them has an image. When
+none of them do the last ZERO_RESULTS is returned, unless a provider failed in which case that is not known for sure.
+*/
+func (c *providerChainStreetViewApiClient) RequestMetadata(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewMetadata, error) {
+	var zeroResults *StreetViewMetadata
+	var failure error
+
+	for index, client := range c.clients {
+		metadata, err := client.RequestMetadata(ctx, latitude, longitude, parameters)
+
+		if err != nil {
+			if _, isApplicationError := err.(Error.ApplicationError); !isApplicationError && !isProviderRefusal(err) {
+				return nil, err
+			}
+
+			failure = err
+			c.logFallback(index, err)
+
+			continue
+		}
+
+		if metadata.Status == MetadataStatusZeroResults {
+			zeroResults = metadata
+			c.logFallback(index, nil)
+
+			continue
+		}
+
+		return metadata, nil
+	}
+
+	if failure != nil {
+		return nil, failure
+	}
+
+	return zeroResults, nil
+}
+
+/*
+requestImage performs the image request against each client in turn until one of them retrieves the image. When none of
+them do the last failure is returned, as the image may well exist, otherwise the last provider's error saying there is
+no image.
+*/
+func (c *providerChainStreetViewApiClient) requestImage(
+	request func(client StreetViewApiClient) (*StreetViewApiImage, error),
+) (*StreetViewApiImage, error) {
+	var noImage, failure error
+
+	for index, client := range c.clients {
+		image, err := request(client)
+
+		if err == nil {
+			return image, nil
+		}
+
+		switch typedErr := err.(type) {
+		case Error.ApplicationError:
+			failure = err
+		case Error.UserError:
+			if isProviderRefusal(err) {
+				failure = err
+
+				break
+			}
+
+			if typedErr.Code != InvalidLocationCode && typedErr.Code != InvalidPanoIdCode {
+				return nil, err
+			}
+
+			noImage = err
+		default:
+			failure = err
+		}
+
+		c.logFallback(index, err)
+	}
+
+	if failure != nil {
+		return nil, failure
+	}
+
+	return nil, noImage
+}
+
+/* isProviderRefusal returns whether the error is a provider refusing the request because of how it is configured. */
+func isProviderRefusal(err error) bool {
+	userErr, isUserError := err.(Error.UserError)
+
+	if !isUserError {
+		return false
+	}
+
+	switch userErr.Code {
+	case ApiKeysQuarantinedCode, QuotaExceededCode, RequestDeniedCode:
+		return true
+	}
+
+	return false
+}
+
+/* logFallback logs why the client at the index was unable to provide an image, a nil error meaning it has none. */
+func (c *providerChainStreetViewApiClient) logFallback(index int, err error) {
+	fallback := "no providers are left to fall back to"
+
+	if index+1 < len(c.clients) {
+		fallback = fmt.Sprintf("falling back to: '%s'", c.clients[index+1].GetProvider())
+	}
+
+	if _, isUserError := err.(Error.UserError); err != nil && (!isUserError || isProviderRefusal(err)) {
+		c.logger.Warning(fmt.Sprintf(
+			"Provider: '%s' failed, %s, error: '%s'", c.clients[index].GetProvider(), fallback, err.Error(),
+		))
+
+		return
+	}
+
+	c.logger.Debug(fmt.Sprintf("Provider: '%s' has no image, %s", c.clients[index].GetProvider(), fallback))
+}
@@ -0,0 +1,72 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"net/http"
+	"time"
+)
+
+/*
+rateLimitedHttpClient decorates a HttpClient with a rate limiter, so that every instance combined stays under the calls
+per second Google permits for the api key. Every http request is a call, including each retry and each call made again
+with another key. Calls are queued until the rate permits them, or fail with a UserError once they would be queued for
+longer than the configured max wait or past the deadline of the request.
+*/
+type rateLimitedHttpClient struct {
+	client  HttpClient
+	limiter StreetViewApiRateLimiter
+	maxWait time.Duration
+}
+
+/* newRateLimitedHttpClient returns a new HttpClient decorated with the rate limiter. */
+func newRateLimitedHttpClient(client HttpClient, limiter StreetViewApiRateLimiter, maxWait time.Duration) HttpClient {
+	return &rateLimitedHttpClient{client: client, limiter: limiter, maxWait: maxWait}
+}
+
+/* Do waits for the rate limit to permit the request, then sends it and returns the response, see: http.Client. */
+func (c *rateLimitedHttpClient) Do(request *http.Request) (*http.Response, error) {
+	if err := c.limiter.Wait(request.Context(), 1, c.calculateDeadline(request)); err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(request)
+}
+
+/*
+calculateDeadline calculates until when a request may be queued: the configured max wait, unless the deadline of the
+request is sooner as there's no point in reserving calls that can no longer be made.
+*/
+func (c *rateLimitedHttpClient) calculateDeadline(request *http.Request) time.Time {
+	deadline := time.Now().Add(c.maxWait)
+
+	if requestDeadline, hasDeadline := request.Context().Deadline(); hasDeadline && requestDeadline.Before(deadline) {
+		return requestDeadline
+	}
+
+	return deadline
+}
+
+/*
+newRateLimitExceededError returns the UserError of the rate limit if the final attempt of a retried call could not be
+made within it, otherwise nil.
+*/
+func newRateLimitExceededError(retryErr error) error {
+	err, isRetryErr := retryErr.(*RetryError)
+
+	if !isRetryErr || len(err.Errs) == 0 {
+		return nil
+	}
+
+	if !isRateLimitExceededError(err.Errs[len(err.Errs)-1]) {
+		return nil
+	}
+
+	return Error.UserError{Code: RateLimitExceededCode, Err: RateLimitExceededErr}
+}
+
+/* isRateLimitExceededError returns whether the error is a call not being made as it exceeded the rate limit. */
+func isRateLimitExceededError(err error) bool {
+	userError, isUserError := err.(Error.UserError)
+
+	return isUserError && userError.Code == RateLimitExceededCode
+}
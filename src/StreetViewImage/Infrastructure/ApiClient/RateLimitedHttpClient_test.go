@@ -0,0 +1,115 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"app/test/FakeStreetView"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+/* countingRateLimiter counts the calls it is asked to wait for, refusing them once it has let the limit through. */
+type countingRateLimiter struct {
+	mutex sync.Mutex
+	calls []int
+	/* limit is the calls let through before every other is refused, 0 for every call. */
+	limit int
+}
+
+func (l *countingRateLimiter) Wait(ctx context.Context, calls int, deadline time.Time) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.limit > 0 && len(l.calls) >= l.limit {
+		return Error.UserError{Code: RateLimitExceededCode, Err: RateLimitExceededErr}
+	}
+
+	l.calls = append(l.calls, calls)
+
+	return nil
+}
+
+/* newTestRateLimitedStreetViewClient returns a client for google's api calling the fake within the rate limiter. */
+func newTestRateLimitedStreetViewClient(
+	t *testing.T, endpoint string, apiKeys string, limiter StreetViewApiRateLimiter,
+) *streetViewApiClient {
+	client, _ := newTestStreetViewClient(t, endpoint, apiKeys, "")
+	client.httpClient = newRateLimitedHttpClient(http.DefaultClient, limiter, time.Second)
+
+	return client
+}
+
+func TestRateLimitedRequestWaitsForEveryAttempt(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(
+		FakeStreetView.Scenario{Endpoint: FakeStreetView.EndpointMetadata, StatusCode: 503, Times: 2},
+		FakeStreetView.Scenario{Endpoint: FakeStreetView.EndpointImage, StatusCode: 403, Times: 1},
+	)
+
+	limiter := &countingRateLimiter{}
+	client := newTestRateLimitedStreetViewClient(t, endpoint, "first,second", limiter)
+
+	if _, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters()); err != nil {
+		t.Fatalf("Expected the image, got error: '%s'", err.Error())
+	}
+
+	/* Three metadata attempts, then the image with the refused key and again with the other one. */
+	if len(limiter.calls) != 5 {
+		t.Errorf("Expected to wait for '5' calls, got: '%d'", len(limiter.calls))
+	}
+
+	for _, calls := range limiter.calls {
+		if calls != 1 {
+			t.Errorf("Expected to wait for each call alone, got: '%v'", limiter.calls)
+
+			break
+		}
+	}
+}
+
+func TestRateLimitedRequestWaitsOnceWhenCoverageIsChecked(t *testing.T) {
+	_, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	limiter := &countingRateLimiter{}
+	client := newTestRateLimitedStreetViewClient(t, endpoint, "key", limiter)
+
+	ctx := WithCheckedCoverage(
+		context.Background(), &StreetViewMetadata{Status: MetadataStatusOk, Provider: GoogleProvider},
+	)
+
+	if _, err := client.Request(ctx, 51.5, -0.1, newTestParameters()); err != nil {
+		t.Fatalf("Expected the image, got error: '%s'", err.Error())
+	}
+
+	if len(limiter.calls) != 1 || limiter.calls[0] != 1 {
+		t.Errorf("Expected to wait for the one image call, got: '%v'", limiter.calls)
+	}
+}
+
+func TestRateLimitedRequestIsNotRetriedOverTheRateLimit(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{Endpoint: FakeStreetView.EndpointMetadata, StatusCode: 503, Times: 1})
+
+	limiter := &countingRateLimiter{limit: 1}
+	client := newTestRateLimitedStreetViewClient(t, endpoint, "key", limiter)
+
+	_, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters())
+
+	assertUserErrorCode(t, err, RateLimitExceededCode)
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 1 {
+		t.Errorf("Expected only the call within the rate limit to be made, got: '%d'", count)
+	}
+
+	/* Exceeding the rate limit says nothing about the health of the api. */
+	if _, err := client.status.GetLastOutcome(GoogleProvider); err != nil {
+		t.Errorf("Expected no failure to be recorded, got: '%s'", err.Error())
+	}
+}
@@ -0,0 +1,56 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"context"
+)
+
+const (
+	/* Error constants. */
+	RequestCancelledCode        = "RequestCancelledCode"
+	RequestCancelledErr         = "the request was cancelled before the image could be retrieved"
+	RequestDeadlineExceededCode = "RequestDeadlineExceededCode"
+	RequestDeadlineExceededErr  = "the deadline of the request passed before the image could be retrieved"
+)
+
+/*
+NewRequestContextError returns the UserError for why the context of the request is done, or nil whilst it is not. The
+caller giving up is not the api failing, so it is not an ApplicationError.
+*/
+func NewRequestContextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return Error.UserError{Code: RequestDeadlineExceededCode, Err: RequestDeadlineExceededErr}
+	}
+
+	return Error.UserError{Code: RequestCancelledCode, Err: RequestCancelledErr}
+}
+
+/* isRequestContextError returns whether the error is the caller having given up, as opposed to the api failing. */
+func isRequestContextError(err error) bool {
+	userError, isUserError := err.(Error.UserError)
+
+	return isUserError && (userError.Code == RequestCancelledCode || userError.Code == RequestDeadlineExceededCode)
+}
+
+/* checkedCoverageKey is the key of the context value holding the metadata of a checked location. */
+type checkedCoverageKey struct{}
+
+/*
+WithCheckedCoverage returns a context saying that the metadata of the location every request made with it is for has
+already been retrieved, so that a provider checking for an image before each billable request needn't do so again. It
+is for a caller making many requests for the one location, such as the slices of a panorama. Only the provider the
+metadata was retrieved from skips it's check, and only when it has an image.
+*/
+func WithCheckedCoverage(ctx context.Context, metadata *StreetViewMetadata) context.Context {
+	return context.WithValue(ctx, checkedCoverageKey{}, metadata)
+}
+
+/* isCoverageChecked returns whether the provider is already known to have an image, see: WithCheckedCoverage. */
+func isCoverageChecked(ctx context.Context, provider string) bool {
+	metadata, isChecked := ctx.Value(checkedCoverageKey{}).(*StreetViewMetadata)
+
+	return isChecked && metadata.Provider == provider && metadata.Status == MetadataStatusOk
+}
@@ -0,0 +1,91 @@
+package ApiClient
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/* maxResponseErrorBody is the most of an unsuccessful response's body kept for logging, as it explains the status. */
+const maxResponseErrorBody = 256
+
+/* responseError is an unsuccessful response from an imagery provider's api, classified by whether to retry it. */
+type responseError struct {
+	statusCode int
+	/* retryable is whether the same request may well succeed when made again. */
+	retryable bool
+	/* retryAfter is how long the api asked to wait before retrying, from the Retry-After header, 0 when it did not. */
+	retryAfter time.Duration
+	/* body is the start of the body of a response that is not retryable, which says what's wrong with the request. */
+	body string
+}
+
+/*
+newResponseError classifies an unsuccessful response: server errors and too many requests are worth retrying, whereas
+any other client error (such as a denied api key) will fail just the same every time. The body is closed as it is of no
+further use.
+*/
+func newResponseError(response *http.Response) error {
+	defer func() { _ = response.Body.Close() }()
+
+	err := &responseError{
+		statusCode: response.StatusCode,
+		retryable:  isRetryableStatus(response.StatusCode),
+		retryAfter: parseRetryAfter(response.Header.Get("Retry-After")),
+	}
+
+	if !err.retryable {
+		body, _ := ioutil.ReadAll(io.LimitReader(response.Body, maxResponseErrorBody))
+		err.body = strings.TrimSpace(string(body))
+	}
+
+	return err
+}
+
+/* Error returns the string representation of the error, as mandated by the error interface. */
+func (e *responseError) Error() string {
+	if e.retryable {
+		return fmt.Sprintf("response status code: '%d'", e.statusCode)
+	}
+
+	return fmt.Sprintf("response status code: '%d' (not retried), body: '%s'", e.statusCode, e.body)
+}
+
+/*
+isRetryable returns whether a call that failed with the error is worth retrying: a response classified as such or any
+other failure, such as the network. A call that could not be made within the rate limit is not, as retrying it would
+only queue for the rate limit again.
+*/
+func isRetryable(err error) bool {
+	if responseErr, isResponseErr := err.(*responseError); isResponseErr {
+		return responseErr.retryable
+	}
+
+	return !isRateLimitExceededError(err)
+}
+
+/* isRetryableStatus returns whether a response with the status code is worth retrying: server errors and 429s. */
+func isRetryableStatus(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
+
+/* parseRetryAfter parses a Retry-After header, given in either seconds or as a http date, 0 when missing or invalid. */
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(header); err == nil && time.Until(at) > 0 {
+		return time.Until(at)
+	}
+
+	return 0
+}
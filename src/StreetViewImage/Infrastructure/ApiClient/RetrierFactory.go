@@ -2,14 +2,161 @@ package ApiClient
 
 import (
 	"app/config"
-	"github.com/j7mbo/MethodCallRetrier/v2"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/j7mbo/go-multierror"
+	"math/rand"
 	"time"
 )
 
-/* RetrierFactory is responsible for creating a Retrier (third-party lib). Can be DI'd for SoC. */
+/*
+Retrier retries a function X times after receiving an error, like the third-party MethodCallRetrier.Retrier does, but
+only errors worth retrying, and gives up as soon as the context of the request is done instead of sleeping past it's
+deadline.
+*/
+type Retrier interface {
+	/* ExecuteFuncWithRetry calls the function until it succeeds, returning a RetryError describing why it did not. */
+	ExecuteFuncWithRetry(function func() error) error
+}
+
+/* RetryError is returned when a function could not be called successfully, along with the attempts made for logging. */
+type RetryError struct {
+	/* Attempts is the number of times the function was called. */
+	Attempts int
+	/* Errs are the errors of each attempt in order, followed by why no more attempts were made if not out of retries. */
+	Errs []error
+}
+
+/* Error returns the string representation of the error, as mandated by the error interface. */
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("attempts made: '%d', errors: '%s'", e.Attempts, multierror.AppendList(e.Errs...).Error())
+}
+
+/* RetrierFactory is responsible for creating a Retrier bound to the context of a request. Can be DI'd for SoC. */
 type RetrierFactory struct{}
 
-/* Create creates a new MethodCallRetrier.Retrier given a config.StreetViewApiConfiguration. */
-func (*RetrierFactory) Create(config *config.StreetViewApiConfiguration) MethodCallRetrier.Retrier {
-	return MethodCallRetrier.New(time.Duration(config.GetRetryDelay())*time.Second, int64(config.GetMaxRetries()), 1)
+/* Create creates a new Retrier for the context given a config.StreetViewApiConfiguration. */
+func (*RetrierFactory) Create(ctx context.Context, config *config.StreetViewApiConfiguration) Retrier {
+	maxRetries := config.GetMaxRetries()
+
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+
+	waitTime := time.Duration(config.GetRetryDelay()) * time.Second
+	maxWaitTime := time.Duration(config.GetMaxRetryDelay()) * time.Second
+
+	if maxWaitTime < waitTime {
+		maxWaitTime = waitTime
+	}
+
+	return &contextRetrier{ctx: ctx, waitTime: waitTime, maxWaitTime: maxWaitTime, maxRetries: maxRetries}
+}
+
+/*
+contextRetrier retries a function with an exponentially growing wait time in between each call, for as long as the
+context permits.
+*/
+type contextRetrier struct {
+	ctx context.Context
+	/* waitTime is the wait time after the first unsuccessful call, doubling after each one after that. */
+	waitTime time.Duration
+	/* maxWaitTime caps the wait time between calls, as well as the Retry-After an api may ask for. */
+	maxWaitTime time.Duration
+	/* maxRetries is the maximum number of calls to make before giving up. */
+	maxRetries int
+}
+
+/*
+ExecuteFuncWithRetry calls the function until it succeeds, returning a RetryError describing why it did not. It gives
+up early, adding the reason to the errors, once an error is not worth retrying (see: isRetryable), the context is done
+or the next wait would not end before it's deadline.
+*/
+func (r *contextRetrier) ExecuteFuncWithRetry(function func() error) error {
+	retryErr := &RetryError{}
+
+	for {
+		if err := r.ctx.Err(); err != nil {
+			retryErr.Errs = append(retryErr.Errs, err)
+
+			return retryErr
+		}
+
+		retryErr.Attempts++
+
+		err := function()
+
+		if err == nil {
+			return nil
+		}
+
+		retryErr.Errs = append(retryErr.Errs, err)
+
+		if !isRetryable(err) || retryErr.Attempts >= r.maxRetries {
+			return retryErr
+		}
+
+		wait, err := r.calculateWait(retryErr.Attempts, err)
+
+		if err != nil {
+			retryErr.Errs = append(retryErr.Errs, err)
+
+			return retryErr
+		}
+
+		if deadline, hasDeadline := r.ctx.Deadline(); hasDeadline && time.Now().Add(wait).After(deadline) {
+			retryErr.Errs = append(retryErr.Errs, errors.New("waiting to retry would exceed the deadline of the request"))
+
+			return retryErr
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+		case <-r.ctx.Done():
+			timer.Stop()
+		}
+	}
+}
+
+/*
+calculateWait calculates the wait before the next call: the wait time doubled for each unsuccessful call after the first
+plus up to half of it again as jitter, capped at the max wait time. A Retry-After the api responded with is waited for
+instead when it is longer, unless it is longer than the max wait time too in which case there's no point retrying.
+*/
+func (r *contextRetrier) calculateWait(attempts int, err error) (time.Duration, error) {
+	wait := r.waitTime
+
+	for doublings := 1; doublings < attempts && wait < r.maxWaitTime; doublings++ {
+		wait *= 2
+	}
+
+	wait = calculateJitter(wait)
+
+	if wait > r.maxWaitTime {
+		wait = r.maxWaitTime
+	}
+
+	if responseErr, isResponseErr := err.(*responseError); isResponseErr && responseErr.retryAfter > wait {
+		if responseErr.retryAfter > r.maxWaitTime {
+			return 0, errors.New(
+				fmt.Sprintf("the api asked to retry after: '%s', longer than the max retry delay", responseErr.retryAfter),
+			)
+		}
+
+		wait = responseErr.retryAfter
+	}
+
+	return wait, nil
+}
+
+/* calculateJitter adds up to half the wait time again, so that concurrent requests do not all retry at once. */
+func calculateJitter(waitTime time.Duration) time.Duration {
+	if waitTime <= 0 {
+		return 0
+	}
+
+	return waitTime + time.Duration(rand.Int63n(int64(waitTime)))/2
 }
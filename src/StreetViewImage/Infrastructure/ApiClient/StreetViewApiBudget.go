@@ -0,0 +1,264 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Infrastructure/Cache"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	/* budgetKeyPrefix prefixes the redis key the billable requests of a month are counted under, by every instance. */
+	budgetKeyPrefix = "street_view_api_budget"
+
+	/* budgetKeyExpiration keeps the count of a month around for long enough to look back at the previous month. */
+	budgetKeyExpiration = time.Duration(62 * 24 * time.Hour)
+
+	/* budgetMonthFormat is the format of the (UTC) calendar month the billable requests are counted for. */
+	budgetMonthFormat = "2006-01"
+
+	/* Error constants. */
+	BudgetExceededCode = "BudgetExceededCode"
+	BudgetExceededErr  = "the monthly street view budget is spent, only previously requested images are available"
+)
+
+/*
+reserveBudgetScript atomically reserves a billable request from the count of the month, refusing to once the budget is
+spent (in which case nothing is reserved) so that concurrent requests can never exceed it between them. It returns the
+count including the reservation, or -1 when refused.
+
+KEYS[1] = count of the month, ARGV = budget (0 meaning unlimited), expiration of the count (seconds).
+*/
+var reserveBudgetScript = redis.NewScript(`
+local budget = tonumber(ARGV[1])
+
+if budget > 0 and (tonumber(redis.call('GET', KEYS[1])) or 0) >= budget then
+	return -1
+end
+
+local count = redis.call('INCR', KEYS[1])
+
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+
+return count
+`)
+
+/* StreetViewApiUsage is the number of billable requests made to the StreetView API in a calendar month. */
+type StreetViewApiUsage struct {
+	/* Month is the UTC calendar month, formatted as: 2006-01. */
+	Month            string
+	BillableRequests int
+	/* Budget is the billable requests permitted in the month, 0 meaning unlimited. */
+	Budget int
+	/* ApiKeys are the calls made with each api key by this instance since it started, see: StreetViewApiKeys. */
+	ApiKeys []StreetViewApiKeyUsage
+}
+
+/*
+StreetViewApiReservation is a billable request reserved from the budget of a month, to be released if the request is
+not billed after all.
+*/
+type StreetViewApiReservation struct {
+	/* key is the redis key of the count of the month reserved from, empty when nothing could be reserved. */
+	key string
+}
+
+/*
+StreetViewApiBudget counts the billable (image) requests made to the StreetView API by every instance each calendar
+month, warning as configured thresholds of the monthly budget are reached and refusing any more once it is spent.
+Metadata requests are free and so not counted.
+*/
+type StreetViewApiBudget interface {
+	/*
+		Reserve counts a billable request about to be made towards the budget of the month, returning a UserError with
+		the BudgetExceededCode instead if the budget is spent.
+	*/
+	Reserve(ctx context.Context) (StreetViewApiReservation, error)
+
+	/*
+		Release gives back the reservation of a request that was not billed, as it failed. It takes no context as the
+		reservation must be given back whether or not the caller is still waiting.
+	*/
+	Release(reservation StreetViewApiReservation)
+
+	/* GetUsage retrieves the billable requests made this month. */
+	GetUsage(ctx context.Context) (*StreetViewApiUsage, error)
+}
+
+/* redisStreetViewApiBudget counts the billable requests made to the StreetView API in redis. */
+type redisStreetViewApiBudget struct {
+	config             *config.StreetViewApiConfiguration
+	redisClientFactory Cache.RedisClientFactory
+	logger             Logger.LoggingStrategy
+
+	/* warnings are the billable request counts at which a warning is logged, calculated from the thresholds. */
+	warnings map[int]int
+}
+
+/* NewStreetViewApiBudget returns a new StreetViewApiBudget. */
+func NewStreetViewApiBudget(
+	config config.StreetViewApiConfiguration, redisClientFactory Cache.RedisClientFactory, logger Logger.LoggingStrategy,
+) StreetViewApiBudget {
+	return &redisStreetViewApiBudget{
+		config:             &config,
+		redisClientFactory: redisClientFactory,
+		logger:             logger,
+		warnings:           calculateBudgetWarnings(config.GetBudgetWarnings(), config.GetMonthlyBudget()),
+	}
+}
+
+/*
+Reserve counts a billable request about to be made towards the budget of the month, returning a UserError with the
+BudgetExceededCode instead if the budget is spent.
+
+As the count is checked and incremented atomically a single request reaches each threshold, however many instances
+there are, so each warning is logged once unless failed requests bring the count back under it. If redis is unavailable
+the request is allowed without anything being reserved, as the budget is not worth failing every request for.
+*/
+func (b *redisStreetViewApiBudget) Reserve(ctx context.Context) (StreetViewApiReservation, error) {
+	var client *redis.Client
+
+	err := Cache.RunWithContext(ctx, func() (err error) {
+		client, err = b.redisClientFactory.Connect()
+
+		return err
+	})
+
+	if ctxErr := NewRequestContextError(ctx); ctxErr != nil {
+		return StreetViewApiReservation{}, ctxErr
+	}
+
+	if err != nil {
+		b.logger.Warning(fmt.Sprintf("Could not reserve from the monthly budget in redis, reason: '%s'", err.Error()))
+
+		return StreetViewApiReservation{}, nil
+	}
+
+	reservation := StreetViewApiReservation{key: b.formatKey(time.Now())}
+	budget := b.config.GetMonthlyBudget()
+
+	if budget < 0 {
+		budget = 0
+	}
+
+	/* Not abandoned with the context, as the reservation must be known of to be released. The script is quick anyway. */
+	count, err := reserveBudgetScript.Run(
+		client, []string{reservation.key}, budget, int(budgetKeyExpiration.Seconds()),
+	).Int64()
+
+	if err != nil {
+		b.logger.Warning(fmt.Sprintf("Could not reserve from the monthly budget in redis, reason: '%s'", err.Error()))
+
+		return StreetViewApiReservation{}, nil
+	}
+
+	if count < 0 {
+		return StreetViewApiReservation{}, Error.UserError{Code: BudgetExceededCode, Err: BudgetExceededErr}
+	}
+
+	if ctxErr := NewRequestContextError(ctx); ctxErr != nil {
+		b.Release(reservation)
+
+		return StreetViewApiReservation{}, ctxErr
+	}
+
+	if percentage, isThreshold := b.warnings[int(count)]; isThreshold {
+		b.logger.Warning(fmt.Sprintf(
+			"%d%% of the monthly StreetView API budget is spent: %d of %d billable requests", percentage, count, budget,
+		))
+	}
+
+	return reservation, nil
+}
+
+/* Release gives back the reservation of a request that was not billed, as it failed. */
+func (b *redisStreetViewApiBudget) Release(reservation StreetViewApiReservation) {
+	if reservation.key == "" {
+		return
+	}
+
+	client, err := b.redisClientFactory.Connect()
+
+	if err == nil {
+		err = client.Decr(reservation.key).Err()
+	}
+
+	if err != nil {
+		b.logger.Warning(fmt.Sprintf("Could not release reservation from redis, reason: '%s'", err.Error()))
+	}
+}
+
+/* GetUsage retrieves the billable requests made this month. */
+func (b *redisStreetViewApiBudget) GetUsage(ctx context.Context) (*StreetViewApiUsage, error) {
+	now := time.Now()
+
+	usage := &StreetViewApiUsage{Month: now.UTC().Format(budgetMonthFormat), Budget: b.config.GetMonthlyBudget()}
+
+	if usage.Budget < 0 {
+		usage.Budget = 0
+	}
+
+	var count int
+
+	err := Cache.RunWithContext(ctx, func() (err error) {
+		client, err := b.redisClientFactory.Connect()
+
+		if err != nil {
+			return err
+		}
+
+		count, err = client.Get(b.formatKey(now)).Int()
+
+		return err
+	})
+
+	if ctxErr := NewRequestContextError(ctx); ctxErr != nil {
+		return nil, ctxErr
+	}
+
+	if err != nil && err != redis.Nil {
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Unable to retrieve the monthly budget from redis, error: '%s'", err.Error()),
+		)
+	}
+
+	usage.BillableRequests = count
+
+	return usage, nil
+}
+
+/* formatKey formats the redis key the billable requests of the month of the time are counted under. */
+func (b *redisStreetViewApiBudget) formatKey(at time.Time) string {
+	return fmt.Sprintf("%s:%s", budgetKeyPrefix, at.UTC().Format(budgetMonthFormat))
+}
+
+/*
+calculateBudgetWarnings converts the comma separated percentages of the budget into the billable request counts they
+are reached at, mapped to the percentage. Invalid percentages are ignored, as is everything when there is no budget.
+*/
+func calculateBudgetWarnings(thresholds string, budget int) map[int]int {
+	warnings := make(map[int]int)
+
+	if budget < 1 {
+		return warnings
+	}
+
+	for _, threshold := range strings.Split(thresholds, ",") {
+		percentage, err := strconv.Atoi(strings.TrimSpace(threshold))
+
+		if err != nil || percentage < 1 || percentage > 100 {
+			continue
+		}
+
+		warnings[int(math.Ceil(float64(budget*percentage)/100))] = percentage
+	}
+
+	return warnings
+}
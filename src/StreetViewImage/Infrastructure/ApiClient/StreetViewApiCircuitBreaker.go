@@ -0,0 +1,211 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/* The states of the circuit breaker. */
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half-open"
+)
+
+/*
+StreetViewApiCircuitBreaker stops calls being made to an imagery provider's API once it is clearly down, so that
+requests fail fast instead of each of them sleeping through every retry first. Each provider has a circuit of it's own,
+so that one provider being down does not stop calls being made to the others.
+
+A circuit starts closed, letting every call through. After the configured number of consecutive failed calls it opens
+and fails every call fast for the cool-down, after which it is half-open: a single trial call is let through at a time,
+and only after the configured number of them succeed does it close again. A failed trial call opens it again straight
+away.
+*/
+type StreetViewApiCircuitBreaker interface {
+	/* Allow returns an ApplicationError if a call to the provider's api must not be made right now, otherwise nil. */
+	Allow(provider string) error
+
+	/*
+		RecordOutcome records the outcome of a call to the provider's api that was allowed, a nil error meaning the call
+		was successful. Only an ApplicationError counts as a failure, a UserError means the api is up and responded as
+		expected.
+	*/
+	RecordOutcome(provider string, err error)
+}
+
+/* streetViewApiCircuitBreaker stops calls being made to an imagery provider's API once it is clearly down. */
+type streetViewApiCircuitBreaker struct {
+	mutex  sync.Mutex
+	logger *Logger.LoggingStrategy
+
+	failureThreshold int
+	successThreshold int
+	coolDown         time.Duration
+
+	/* circuits are the circuits of each provider, created closed on the first call to the provider. */
+	circuits map[string]*circuit
+}
+
+/* circuit is the state of the breaker for a single provider, only ever accessed with the breaker's lock held. */
+type circuit struct {
+	state    string
+	openedAt time.Time
+
+	/* consecutiveFailures counts failed calls whilst closed, successes counts successful trial calls whilst half-open. */
+	consecutiveFailures int
+	successes           int
+
+	/* trialInFlight is true whilst half-open and the single trial call allowed has not had it's outcome recorded. */
+	trialInFlight bool
+}
+
+/* NewStreetViewApiCircuitBreaker returns a new StreetViewApiCircuitBreaker. */
+func NewStreetViewApiCircuitBreaker(
+	config config.StreetViewApiConfiguration, logger *Logger.LoggingStrategy,
+) StreetViewApiCircuitBreaker {
+	successThreshold := config.GetBreakerSuccesses()
+
+	if successThreshold < 1 {
+		successThreshold = 1
+	}
+
+	return &streetViewApiCircuitBreaker{
+		logger:           logger,
+		failureThreshold: config.GetBreakerFailures(),
+		successThreshold: successThreshold,
+		coolDown:         time.Duration(config.GetBreakerCoolDown()) * time.Second,
+		circuits:         make(map[string]*circuit),
+	}
+}
+
+/* Allow returns an ApplicationError if a call to the provider's api must not be made right now, otherwise nil. */
+func (b *streetViewApiCircuitBreaker) Allow(provider string) error {
+	if b.failureThreshold < 1 {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c := b.retrieveCircuit(provider)
+
+	switch c.state {
+	case breakerOpen:
+		if time.Since(c.openedAt) < b.coolDown {
+			return Error.NewApplicationError(fmt.Sprintf(
+				"Circuit breaker of provider: '%s' is open, failing fast until: '%s'",
+				provider, c.openedAt.Add(b.coolDown).Format(time.RFC3339),
+			))
+		}
+
+		b.transition(provider, c, breakerHalfOpen)
+		c.trialInFlight = true
+
+		return nil
+	case breakerHalfOpen:
+		if c.trialInFlight {
+			return Error.NewApplicationError(fmt.Sprintf(
+				"Circuit breaker of provider: '%s' is half-open and already waiting on a trial call, failing fast",
+				provider,
+			))
+		}
+
+		c.trialInFlight = true
+
+		return nil
+	}
+
+	return nil
+}
+
+/*
+RecordOutcome records the outcome of a call to the provider's api that was allowed, a nil error meaning the call was
+successful. Only an ApplicationError counts as a failure, a UserError means the api is up and responded as expected.
+*/
+func (b *streetViewApiCircuitBreaker) RecordOutcome(provider string, err error) {
+	if b.failureThreshold < 1 {
+		return
+	}
+
+	_, failed := err.(Error.ApplicationError)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c := b.retrieveCircuit(provider)
+
+	/*
+		Neither the caller giving up nor the call exceeding the rate limit say anything about the api, other than that the
+		trial call of a half-open breaker is over.
+	*/
+	if isRequestContextError(err) || isRateLimitExceededError(err) {
+		if c.state == breakerHalfOpen {
+			c.trialInFlight = false
+		}
+
+		return
+	}
+
+	switch c.state {
+	case breakerClosed:
+		if !failed {
+			c.consecutiveFailures = 0
+
+			return
+		}
+
+		c.consecutiveFailures++
+
+		if c.consecutiveFailures >= b.failureThreshold {
+			b.open(provider, c)
+		}
+	case breakerHalfOpen:
+		c.trialInFlight = false
+
+		if failed {
+			b.open(provider, c)
+
+			return
+		}
+
+		c.successes++
+
+		if c.successes >= b.successThreshold {
+			b.transition(provider, c, breakerClosed)
+		}
+	}
+
+	/* Outcomes of calls that were allowed before the breaker opened are of no interest to an open breaker. */
+}
+
+/* retrieveCircuit retrieves the provider's circuit, created closed if need be. Must be called with the lock held. */
+func (b *streetViewApiCircuitBreaker) retrieveCircuit(provider string) *circuit {
+	c, exists := b.circuits[provider]
+
+	if !exists {
+		c = &circuit{state: breakerClosed}
+		b.circuits[provider] = c
+	}
+
+	return c
+}
+
+/* open opens the provider's circuit, failing every call to it fast from now until the cool-down is over. */
+func (b *streetViewApiCircuitBreaker) open(provider string, c *circuit) {
+	c.openedAt = time.Now()
+	b.transition(provider, c, breakerOpen)
+}
+
+/* transition moves the circuit to the state, resetting the counters, and logs it. Must be called with the lock held. */
+func (b *streetViewApiCircuitBreaker) transition(provider string, c *circuit, state string) {
+	b.logger.Warning(fmt.Sprintf(
+		"Circuit breaker of provider: '%s' transitioning from %s to %s", provider, c.state, state,
+	))
+
+	c.state, c.consecutiveFailures, c.successes, c.trialInFlight = state, 0, 0, false
+}
@@ -0,0 +1,9 @@
+package ApiClient
+
+/* StreetViewApiImage is an image retrieved from an imagery provider's API, along with which provider retrieved it. */
+type StreetViewApiImage struct {
+	/* Bytes are the raw bytes of the image, as returned by the provider. */
+	Bytes []byte
+	/* Provider is the name of the imagery provider the image was retrieved from. */
+	Provider string
+}
@@ -0,0 +1,334 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	/* apiKeySeparator separates the configured keys, apiKeyPartSeparator separates a key from it's weight and secret. */
+	apiKeySeparator     = ","
+	apiKeyPartSeparator = ":"
+
+	/* apiKeyIdLength is the number of characters at the end of a key that identify it in logs and usage. */
+	apiKeyIdLength = 4
+
+	/* Error constants. */
+	ApiKeysQuarantinedCode = "ApiKeysQuarantinedCode"
+	ApiKeysQuarantinedErr  = "every street view api key is over it's quota or denied, please retry the request later"
+)
+
+/* StreetViewApiKey is one of the keys Google's StreetView API is called with. */
+type StreetViewApiKey struct {
+	Key string
+	/* Weight is the share of calls made with the key, relative to the weights of the other keys. */
+	Weight int
+	/* SigningSecret is the signing secret of the key's project, the configured signing secret unless given with it. */
+	SigningSecret string
+}
+
+/* GetId retrieves an identifier of the key that is safe to log: the last few characters of it. */
+func (k *StreetViewApiKey) GetId() string {
+	if len(k.Key) <= apiKeyIdLength {
+		return strings.Repeat("*", apiKeyIdLength)
+	}
+
+	return strings.Repeat("*", apiKeyIdLength) + k.Key[len(k.Key)-apiKeyIdLength:]
+}
+
+/* StreetViewApiKeyUsage is the number of calls made with a key by this instance, since it started. */
+type StreetViewApiKeyUsage struct {
+	/* Id is the identifier of the key that is safe to share, see: StreetViewApiKey.GetId. */
+	Id     string
+	Weight int
+	/* Requests is every call made with the key that google responded to, BillableRequests the images among them. */
+	Requests         int
+	BillableRequests int
+	/* Refusals is the number of times google refused the key and it was quarantined. */
+	Refusals int
+	/* QuarantinedUntil is when the key is used again, the zero time when it is not quarantined. */
+	QuarantinedUntil time.Time
+}
+
+/*
+StreetViewApiKeys rotates between the configured keys to call Google's StreetView API with, in proportion to their
+weights. A key google refuses as over it's quota or denied is quarantined for the configured cool-down, during which
+the calls are made with the other keys.
+*/
+type StreetViewApiKeys interface {
+	/* Next retrieves the key to make the next call with, or a UserError with the ApiKeysQuarantinedCode if none. */
+	Next() (*StreetViewApiKey, error)
+
+	/* RecordRequest counts a call made with the key that google responded to, billable when an image was returned. */
+	RecordRequest(key *StreetViewApiKey, billable bool)
+
+	/* Quarantine stops the key being used for the cool-down, as google refused it for the reason. */
+	Quarantine(key *StreetViewApiKey, reason string)
+
+	/* GetUsage retrieves the calls made with each key, in the order they are configured. */
+	GetUsage() []StreetViewApiKeyUsage
+}
+
+/* streetViewApiKeys rotates between the configured keys with a smooth weighted round robin. */
+type streetViewApiKeys struct {
+	mutex  sync.Mutex
+	logger *Logger.LoggingStrategy
+
+	quarantine time.Duration
+
+	keys []*apiKeyState
+}
+
+/* apiKeyState is the state of the rotation and the counters of a single key, only accessed with the lock held. */
+type apiKeyState struct {
+	key StreetViewApiKey
+
+	/* currentWeight grows by the key's weight every rotation and shrinks by the total weight when the key is chosen. */
+	currentWeight int
+
+	requests         int
+	billableRequests int
+	refusals         int
+	quarantinedUntil time.Time
+}
+
+/* NewStreetViewApiKeys returns a new StreetViewApiKeys for the configured keys, or the single api key if none. */
+func NewStreetViewApiKeys(config config.StreetViewApiConfiguration, logger *Logger.LoggingStrategy) StreetViewApiKeys {
+	quarantine := time.Duration(config.GetApiKeyQuarantine()) * time.Second
+
+	if quarantine < time.Second {
+		quarantine = time.Second
+	}
+
+	keys := parseApiKeys(config.GetApiKeys(), config.GetSigningSecret(), logger)
+
+	if len(keys) == 0 {
+		keys = append(keys, &apiKeyState{
+			key: StreetViewApiKey{Key: config.GetApiKey(), Weight: 1, SigningSecret: config.GetSigningSecret()},
+		})
+	}
+
+	return &streetViewApiKeys{logger: logger, quarantine: quarantine, keys: keys}
+}
+
+/*
+Next retrieves the key to make the next call with, or a UserError with the ApiKeysQuarantinedCode if none.
+
+Of the keys that are not quarantined, each one's current weight grows by it's weight and the one with the highest is
+chosen, it's current weight then shrinking by the total. This spreads the calls made with each key evenly between
+those of the others, rather than in bursts.
+*/
+func (k *streetViewApiKeys) Next() (*StreetViewApiKey, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	now := time.Now()
+
+	var chosen *apiKeyState
+
+	totalWeight := 0
+
+	for _, state := range k.keys {
+		if now.Before(state.quarantinedUntil) {
+			continue
+		}
+
+		state.currentWeight += state.key.Weight
+		totalWeight += state.key.Weight
+
+		if chosen == nil || state.currentWeight > chosen.currentWeight {
+			chosen = state
+		}
+	}
+
+	if chosen == nil {
+		return nil, Error.UserError{Code: ApiKeysQuarantinedCode, Err: ApiKeysQuarantinedErr}
+	}
+
+	chosen.currentWeight -= totalWeight
+
+	return &chosen.key, nil
+}
+
+/* RecordRequest counts a call made with the key that google responded to, billable when an image was returned. */
+func (k *streetViewApiKeys) RecordRequest(key *StreetViewApiKey, billable bool) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	state := k.retrieveState(key)
+
+	if state == nil {
+		return
+	}
+
+	state.requests++
+
+	if billable {
+		state.billableRequests++
+	}
+}
+
+/*
+Quarantine stops the key being used for the cool-down, as google refused it for the reason. Concurrent calls made with
+the key are likely refused too, so a key that is already quarantined is not quarantined for any longer.
+*/
+func (k *streetViewApiKeys) Quarantine(key *StreetViewApiKey, reason string) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	state := k.retrieveState(key)
+
+	if state == nil {
+		return
+	}
+
+	state.refusals++
+
+	now := time.Now()
+
+	if now.Before(state.quarantinedUntil) {
+		return
+	}
+
+	state.quarantinedUntil = now.Add(k.quarantine)
+
+	/* The key no longer competes for calls whilst quarantined, so it rejoins the rotation from scratch. */
+	state.currentWeight = 0
+
+	k.logger.Warning(fmt.Sprintf(
+		"StreetView api key: '%s' quarantined for: '%s', reason: '%s'", key.GetId(), k.quarantine, reason,
+	))
+
+	for _, other := range k.keys {
+		if !now.Before(other.quarantinedUntil) {
+			return
+		}
+	}
+
+	k.logger.Error("Every StreetView api key is quarantined, requests that miss the cache will fail until one is not")
+}
+
+/* GetUsage retrieves the calls made with each key, in the order they are configured. */
+func (k *streetViewApiKeys) GetUsage() []StreetViewApiKeyUsage {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	now := time.Now()
+
+	usage := make([]StreetViewApiKeyUsage, 0, len(k.keys))
+
+	for _, state := range k.keys {
+		keyUsage := StreetViewApiKeyUsage{
+			Id:               state.key.GetId(),
+			Weight:           state.key.Weight,
+			Requests:         state.requests,
+			BillableRequests: state.billableRequests,
+			Refusals:         state.refusals,
+		}
+
+		if now.Before(state.quarantinedUntil) {
+			keyUsage.QuarantinedUntil = state.quarantinedUntil
+		}
+
+		usage = append(usage, keyUsage)
+	}
+
+	return usage
+}
+
+/* retrieveState retrieves the state of a key returned by Next, nil if it is not one of ours. Requires the lock held. */
+func (k *streetViewApiKeys) retrieveState(key *StreetViewApiKey) *apiKeyState {
+	for _, state := range k.keys {
+		if &state.key == key {
+			return state
+		}
+	}
+
+	return nil
+}
+
+/*
+parseApiKeys parses the comma separated keys, each as key:weight or key:weight:signing-secret, the weight defaulting
+to 1 and the signing secret to the configured one. Keys with an invalid weight are logged and ignored, as are repeats.
+*/
+func parseApiKeys(keys string, signingSecret string, logger *Logger.LoggingStrategy) []*apiKeyState {
+	var states []*apiKeyState
+
+	seen := make(map[string]bool)
+
+	for _, configured := range strings.Split(keys, apiKeySeparator) {
+		parts := strings.SplitN(strings.TrimSpace(configured), apiKeyPartSeparator, 3)
+
+		key := StreetViewApiKey{Key: parts[0], Weight: 1, SigningSecret: signingSecret}
+
+		if key.Key == "" || seen[key.Key] {
+			continue
+		}
+
+		if len(parts) > 1 {
+			weight, err := strconv.Atoi(parts[1])
+
+			if err != nil || weight < 1 {
+				logger.Error(fmt.Sprintf("Invalid weight: '%s' for api key: '%s', ignoring the key", parts[1], key.GetId()))
+
+				continue
+			}
+
+			key.Weight = weight
+		}
+
+		if len(parts) > 2 {
+			key.SigningSecret = parts[2]
+		}
+
+		seen[key.Key] = true
+		states = append(states, &apiKeyState{key: key})
+	}
+
+	return states
+}
+
+/*
+apiKeyRefusedError is returned by a call that google refused because of the key it was made with, such as the key being
+over it's quota or not permitted to use the api, so that the call can be made again with another key.
+*/
+type apiKeyRefusedError struct {
+	reason string
+	/* err is the error to return when there are no more keys to make the call with. */
+	err error
+}
+
+/* Error returns the string representation of the error, as mandated by the error interface. */
+func (e *apiKeyRefusedError) Error() string {
+	return fmt.Sprintf("api key refused, reason: '%s'", e.reason)
+}
+
+/*
+newApiKeyRefusedError returns an apiKeyRefusedError if the final attempt of a retried call was refused with a 403, the
+status google responds to image requests with when the key is over it's quota or denied, otherwise nil.
+*/
+func newApiKeyRefusedError(retryErr error) error {
+	err, isRetryErr := retryErr.(*RetryError)
+
+	if !isRetryErr || len(err.Errs) == 0 {
+		return nil
+	}
+
+	responseErr, isResponseErr := err.Errs[len(err.Errs)-1].(*responseError)
+
+	if !isResponseErr || responseErr.statusCode != http.StatusForbidden {
+		return nil
+	}
+
+	return &apiKeyRefusedError{
+		reason: responseErr.Error(),
+		err:    Error.UserError{Code: RequestDeniedCode, Err: RequestDeniedErr},
+	}
+}
@@ -0,0 +1,163 @@
+package ApiClient
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Infrastructure/Cache"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"time"
+)
+
+const (
+	/* rateLimitKey is the redis key the token bucket shared by every instance is stored under. */
+	rateLimitKey = "street_view_api_rate_limit"
+
+	/* Error constants. */
+	RateLimitExceededCode = "RateLimitExceededCode"
+	RateLimitExceededErr  = "too many requests are being made to the street view api right now, retry the request later"
+)
+
+/*
+reserveTokensScript atomically refills the token bucket for the time passed since it was last used and reserves the
+requested tokens from it, returning the milliseconds the caller must wait until it's reservation is due or -1 when that
+would be longer than it is willing to wait (in which case nothing is reserved).
+
+The bucket may go negative: each caller reserves the tokens that will be refilled next, which queues callers fairly in
+the order they arrived instead of them all retrying at once. The time of the redis server is used so that the clocks of
+the instances sharing the bucket do not have to agree.
+
+KEYS[1] = bucket, ARGV = rate (tokens / second), burst (max tokens), tokens to reserve, max wait (milliseconds).
+*/
+var reserveTokensScript = redis.NewScript(`
+redis.replicate_commands()
+
+local rate, burst, requested, maxWait = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3]), tonumber(ARGV[4])
+
+local time = redis.call('TIME')
+local now = time[1] * 1000 + math.floor(time[2] / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens, updatedAt = tonumber(bucket[1]) or burst, tonumber(bucket[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - updatedAt) * rate / 1000) - requested
+
+local wait = 0
+
+if tokens < 0 then
+	wait = math.ceil(-tokens * 1000 / rate)
+end
+
+if wait > maxWait then
+	return -1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + wait + 1000)
+
+return wait
+`)
+
+/*
+StreetViewApiRateLimiter limits the calls made to the StreetView API by every instance combined to a configured rate
+with a token bucket stored in redis, as Google enforces it's limits per api key and not per instance.
+*/
+type StreetViewApiRateLimiter interface {
+	/*
+		Wait blocks until the given number of calls may be made, returning a UserError with the RateLimitExceededCode
+		instead if that would take longer than the deadline. It stops waiting once the context is done.
+	*/
+	Wait(ctx context.Context, calls int, deadline time.Time) error
+}
+
+/* redisStreetViewApiRateLimiter limits the calls made to the StreetView API with a token bucket stored in redis. */
+type redisStreetViewApiRateLimiter struct {
+	config             *config.StreetViewApiConfiguration
+	redisClientFactory Cache.RedisClientFactory
+	logger             Logger.LoggingStrategy
+}
+
+/* NewStreetViewApiRateLimiter returns a new StreetViewApiRateLimiter. */
+func NewStreetViewApiRateLimiter(
+	config config.StreetViewApiConfiguration, redisClientFactory Cache.RedisClientFactory, logger Logger.LoggingStrategy,
+) StreetViewApiRateLimiter {
+	return &redisStreetViewApiRateLimiter{config: &config, redisClientFactory: redisClientFactory, logger: logger}
+}
+
+/*
+Wait blocks until the given number of calls may be made, returning a UserError with the RateLimitExceededCode instead if
+that would take longer than the deadline. It stops waiting once the context is done, the calls reserved being wasted.
+
+If redis is unavailable the calls are let through, as the rate limit protecting the api key is not worth failing every
+request for.
+*/
+func (l *redisStreetViewApiRateLimiter) Wait(ctx context.Context, calls int, deadline time.Time) error {
+	if l.config.GetRateLimit() < 1 {
+		return nil
+	}
+
+	var client *redis.Client
+
+	err := Cache.RunWithContext(ctx, func() (err error) {
+		client, err = l.redisClientFactory.Connect()
+
+		return err
+	})
+
+	if ctxErr := NewRequestContextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+
+	if err != nil {
+		l.logger.Warning(err.Error())
+
+		return nil
+	}
+
+	burst := l.config.GetRateLimitBurst()
+
+	if burst < calls {
+		burst = calls
+	}
+
+	var wait int64
+
+	err = Cache.RunWithContext(ctx, func() (err error) {
+		wait, err = reserveTokensScript.Run(
+			client, []string{rateLimitKey}, l.config.GetRateLimit(), burst, calls, time.Until(deadline).Milliseconds(),
+		).Int64()
+
+		return err
+	})
+
+	if ctxErr := NewRequestContextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+
+	if err != nil {
+		l.logger.Warning(fmt.Sprintf("Could not reserve from the rate limit in redis, reason: '%s'", err.Error()))
+
+		return nil
+	}
+
+	if wait < 0 {
+		return Error.UserError{Code: RateLimitExceededCode, Err: RateLimitExceededErr}
+	}
+
+	if wait > 0 {
+		l.logger.Debug(fmt.Sprintf("Rate limit reached, waiting '%dms' to call the StreetView API", wait))
+
+		timer := time.NewTimer(time.Duration(wait) * time.Millisecond)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return NewRequestContextError(ctx)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package ApiClient
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+StreetViewApiStatus records the outcome of the last call made to each imagery provider's API, so that the health of the
+API can be reported without having to make (and pay for) a call just to check it. Each provider's outcomes are recorded
+separately, so that one provider failing is neither reported for, nor hidden by, another provider in the chain.
+*/
+type StreetViewApiStatus interface {
+	/* RecordOutcome records the outcome of a call to the provider's api, a nil error meaning the call was successful. */
+	RecordOutcome(provider string, err error)
+
+	/*
+		GetLastOutcome retrieves when the last call to the provider's api was made and it's error, a zero time meaning no
+		call was made yet.
+	*/
+	GetLastOutcome(provider string) (time.Time, error)
+}
+
+/* streetViewApiStatus records the outcome of the last call made to each imagery provider's API. */
+type streetViewApiStatus struct {
+	mutex    sync.RWMutex
+	outcomes map[string]apiOutcome
+}
+
+/* apiOutcome is the outcome of the last call made to a provider's api. */
+type apiOutcome struct {
+	calledAt time.Time
+	err      error
+}
+
+/* NewStreetViewApiStatus returns a new StreetViewApiStatus. */
+func NewStreetViewApiStatus() StreetViewApiStatus {
+	return &streetViewApiStatus{outcomes: make(map[string]apiOutcome)}
+}
+
+/* RecordOutcome records the outcome of a call to the provider's api, a nil error meaning the call was successful. */
+func (s *streetViewApiStatus) RecordOutcome(provider string, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.outcomes[provider] = apiOutcome{calledAt: time.Now(), err: err}
+}
+
+/*
+GetLastOutcome retrieves when the last call to the provider's api was made and it's error, a zero time meaning no call
+was made yet.
+*/
+func (s *streetViewApiStatus) GetLastOutcome(provider string) (time.Time, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	outcome := s.outcomes[provider]
+
+	return outcome.calledAt, outcome.err
+}
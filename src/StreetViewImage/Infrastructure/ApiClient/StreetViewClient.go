@@ -3,88 +3,250 @@ package ApiClient
 import (
 	"app/config"
 	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
 	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/j7mbo/MethodCallRetrier/v2"
-	"github.com/j7mbo/go-multierror"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	/* Request timeout - standard resiliency problem. */
-	requestTimeout = time.Duration(5 * time.Second)
-
 	/* Max sizes from: https://developers.google.com/maps/documentation/streetview/usage-and-billing. */
-	maxWidth  = 640
-	maxHeight = 640
+	MaxWidth  = 640
+	MaxHeight = 640
+
+	/* The GET parameters an image can be requested by, either a location or a specific panorama. */
+	locationParameter = "location"
+	panoParameter     = "pano"
+
+	/* GoogleProvider is the name of the Google imagery provider, the default. */
+	GoogleProvider = Domain.ProviderDefault
 
 	/* Error constants. */
 	InvalidLocationCode    = "InvalidLocationCode"
 	InvalidLocationCodeErr = "invalid location provided: the coordinates do not correspond to a valid street view image"
+	InvalidPanoIdCode      = "InvalidPanoIdCode"
+	InvalidPanoIdErr       = "invalid pano id provided: the id does not correspond to a valid street view panorama"
 )
 
-/* StreetViewApiClient handles requests to Google's Street View API. */
+/* StreetViewApiClient handles requests to an imagery provider's API, Google's Street View API by default. */
 type StreetViewApiClient interface {
-	/* Request performs a request to the street view api with the runtime provided latitude and longitude. */
-	Request(latitude float64, longitude float64) ([]byte, error)
+	/* GetProvider retrieves the name of the imagery provider the client retrieves images from. */
+	GetProvider() string
+
+	/*
+		Request performs a request to the street view api with the runtime provided latitude, longitude and parameters.
+		Every request gives up with a UserError once the context is done, see: NewRequestContextError.
+	*/
+	Request(
+		ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+	) (*StreetViewApiImage, error)
+
+	/* RequestByPanoId performs a request to the street view api for a specific panorama and the parameters. */
+	RequestByPanoId(ctx context.Context, panoId string, parameters *Domain.ImageParameters) (*StreetViewApiImage, error)
+
+	/* RequestMetadata performs a request to the (free) metadata endpoint for the location and parameters. */
+	RequestMetadata(
+		ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+	) (*StreetViewMetadata, error)
 }
 
 /* streetViewApiClient handles requests to Google's Street View API. */
 type streetViewApiClient struct {
-	config  *config.StreetViewApiConfiguration
-	retrier MethodCallRetrier.Retrier
-	logger  Logger.LoggingStrategy
+	config *config.StreetViewApiConfiguration
+	logger Logger.LoggingStrategy
+
+	/* retrierFactory creates a retrier per request, as a retrier keeps state and requests can run concurrently. */
+	retrierFactory RetrierFactory
+
+	/* httpClient is shared by every api client so that connections are pooled, each request waiting for the rate limit. */
+	httpClient HttpClient
+
+	/* keys rotates between the api keys the calls are made with. */
+	keys StreetViewApiKeys
+
+	/* status records the outcome of every call, for health checking. */
+	status StreetViewApiStatus
 }
 
-/* NewStreetViewApiClient returns a new StreetViewApiClient. */
+/*
+NewStreetViewApiClient returns a new StreetViewApiClient for the configured imagery providers, trying each of them in
+turn when there is more than one. Unknown providers are ignored, falling back to Google if none are left.
+
+Every provider fails fast via the breaker whilst it's api is down. Google's client also refuses image requests once the
+monthly budget is spent, queues calls to stay within the rate limit and rotates between the api keys.
+*/
 func NewStreetViewApiClient(
-	config config.StreetViewApiConfiguration, retrierFactory RetrierFactory, logger Logger.LoggingStrategy,
+	config config.StreetViewApiConfiguration,
+	mapillaryConfig config.MapillaryConfiguration,
+	retrierFactory RetrierFactory,
+	httpClient HttpClient,
+	keys StreetViewApiKeys,
+	logger Logger.LoggingStrategy,
+	status StreetViewApiStatus,
+	breaker StreetViewApiCircuitBreaker,
+	limiter StreetViewApiRateLimiter,
+	budget StreetViewApiBudget,
 ) StreetViewApiClient {
-	retrier := retrierFactory.Create(&config)
+	var clients []StreetViewApiClient
+
+	for _, provider := range strings.Split(config.GetProviders(), providerSeparator) {
+		switch strings.TrimSpace(provider) {
+		case GoogleProvider:
+			clients = append(clients, newGoogleStreetViewApiClient(
+				&config, retrierFactory, httpClient, keys, logger, status, breaker, limiter, budget,
+			))
+		case MapillaryProvider:
+			clients = append(clients, newCircuitBreakingStreetViewApiClient(
+				newMapillaryStreetViewApiClient(&mapillaryConfig, &config, retrierFactory, httpClient, logger, status),
+				breaker,
+			))
+		default:
+			logger.Error(fmt.Sprintf("Unknown imagery provider: '%s' configured, ignoring it", provider))
+		}
+	}
 
-	return &streetViewApiClient{config: &config, retrier: retrier, logger: logger}
+	switch len(clients) {
+	case 0:
+		logger.Error(fmt.Sprintf("No known imagery providers configured, using: '%s'", GoogleProvider))
+
+		return newGoogleStreetViewApiClient(
+			&config, retrierFactory, httpClient, keys, logger, status, breaker, limiter, budget,
+		)
+	case 1:
+		return clients[0]
+	}
+
+	return newProviderChainStreetViewApiClient(clients, logger)
 }
 
-/* Request performs a request to the street view api with the runtime provided latitude and longitude. */
-func (c *streetViewApiClient) Request(latitude float64, longitude float64) ([]byte, error) {
-	uri, err := url.Parse(c.config.GetEndpoint())
+/*
+newGoogleStreetViewApiClient returns a new StreetViewApiClient for Google's Street View API, refusing image requests
+once the monthly budget is spent, failing fast via the breaker whilst the api is down and queueing every http request,
+retries included, to stay within the rate limit.
+*/
+func newGoogleStreetViewApiClient(
+	config *config.StreetViewApiConfiguration,
+	retrierFactory RetrierFactory,
+	httpClient HttpClient,
+	keys StreetViewApiKeys,
+	logger Logger.LoggingStrategy,
+	status StreetViewApiStatus,
+	breaker StreetViewApiCircuitBreaker,
+	limiter StreetViewApiRateLimiter,
+	budget StreetViewApiBudget,
+) StreetViewApiClient {
+	return newBudgetedStreetViewApiClient(
+		newCircuitBreakingStreetViewApiClient(
+			&streetViewApiClient{
+				config:         config,
+				retrierFactory: retrierFactory,
+				httpClient: newRateLimitedHttpClient(
+					httpClient, limiter, time.Duration(config.GetRateLimitMaxWait())*time.Second,
+				),
+				keys:   keys,
+				logger: logger,
+				status: status,
+			},
+			breaker,
+		),
+		budget,
+	)
+}
+
+/* GetProvider retrieves the name of the imagery provider the client retrieves images from. */
+func (c *streetViewApiClient) GetProvider() string {
+	return GoogleProvider
+}
+
+/* Request performs a request to the street view api with the runtime provided latitude, longitude and parameters. */
+func (c *streetViewApiClient) Request(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	uri, err := c.buildUrl(locationParameter, c.buildLocationString(latitude, longitude), parameters)
 
-	/* throw new DevRetardationException. */
 	if err != nil {
-		return nil, Error.NewApplicationError(
-			fmt.Sprintf(
-				"Unable to build url for request. Endpoint: '%s', error: '%s'", c.config.GetEndpoint(), err.Error(),
-			),
-		)
+		return nil, err
 	}
 
-	uri = c.addQueryToUrl(*uri, latitude, longitude)
+	return c.requestImage(ctx, uri, Error.UserError{Code: InvalidLocationCode, Err: InvalidLocationCodeErr})
+}
+
+/* RequestByPanoId performs a request to the street view api for a specific panorama and the parameters. */
+func (c *streetViewApiClient) RequestByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) (*StreetViewApiImage, error) {
+	uri, err := c.buildUrl(panoParameter, panoId, parameters)
 
-	if !c.streetviewImageExistsInGoogle(uri) {
-		return nil, Error.UserError{Code: InvalidLocationCode, Err: InvalidLocationCodeErr}
+	if err != nil {
+		return nil, err
 	}
 
-	/* Remove the key from the url so it can be logged without sharing anything dangerous. */
-	uriStringForLogging := regexp.MustCompile(`key=[^&]*`).ReplaceAllString(uri.String(), "${1}")
+	return c.requestImage(ctx, uri, Error.UserError{Code: InvalidPanoIdCode, Err: InvalidPanoIdErr})
+}
 
-	c.logger.Debug(fmt.Sprintf("Making request to: %s", uriStringForLogging))
+/*
+requestImage performs the image request for a built url, returning the notFound error when google has no image for it
+so that the caller decides what the user did wrong. Whether google has the image is not checked again when the caller
+already has, see: WithCheckedCoverage.
+*/
+func (c *streetViewApiClient) requestImage(
+	ctx context.Context, uri *url.URL, notFound Error.UserError,
+) (*StreetViewApiImage, error) {
+	if !isCoverageChecked(ctx, GoogleProvider) {
+		exists, err := c.streetviewImageExistsInGoogle(ctx, uri)
 
-	var res *http.Response
+		/* The api being unreachable is not the user's fault, so it must not be mistaken for the image not existing. */
+		if err != nil {
+			return nil, err
+		}
 
-	defer func() {
-		if res != nil {
-			_ = res.Body.Close()
+		if !exists {
+			return nil, notFound
 		}
-	}()
+	}
+
+	var image *StreetViewApiImage
+
+	err := c.callWithApiKeys(func(key *StreetViewApiKey) (err error) {
+		image, err = c.requestImageWithApiKey(ctx, uri, key)
+
+		return err
+	})
+
+	return image, err
+}
+
+/* requestImageWithApiKey performs the image request for a built url with the api key. */
+func (c *streetViewApiClient) requestImageWithApiKey(
+	ctx context.Context, uri *url.URL, key *StreetViewApiKey,
+) (*StreetViewApiImage, error) {
+	keyedUri := addApiKeyToUrl(*uri, key.Key)
+
+	uriStringForLogging := redactUrl(keyedUri)
+
+	signedUri, err := signUrl(keyedUri, key.SigningSecret)
 
-	errs, wasSuccessful := c.retrier.ExecuteFuncWithRetry(func() error {
-		response, err := (&http.Client{Timeout: requestTimeout}).Get(uri.String())
+	if err != nil {
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Unable to sign url: '%s', error: '%s'", uriStringForLogging, err.Error()),
+		)
+	}
+
+	c.logger.Debug(fmt.Sprintf("Making request to: %s", uriStringForLogging))
+
+	var resBytes []byte
+
+	/* The body is read within the retry, so that a body cut short by the network is requested again. */
+	retryErr := c.retrierFactory.Create(ctx, c.config).ExecuteFuncWithRetry(func() error {
+		response, err := get(ctx, c.httpClient, signedUri)
 
 		if err != nil {
 			return err
@@ -94,34 +256,64 @@ func (c *streetViewApiClient) Request(latitude float64, longitude float64) ([]by
 			return Error.NewApplicationError("No response from StreetView api...")
 		}
 
-		if response.StatusCode != 200 {
-			return Error.NewApplicationError(
-				fmt.Sprintf("response status code: '%d', full response: '%v'", response.StatusCode, res),
-			)
+		c.keys.RecordRequest(key, response.StatusCode == http.StatusOK)
+
+		if response.StatusCode != http.StatusOK {
+			return newResponseError(response)
 		}
 
-		res = response
+		defer func() { _ = response.Body.Close() }()
+
+		if resBytes, err = ioutil.ReadAll(response.Body); err != nil {
+			return errors.New(fmt.Sprintf("unable to read image response, error: '%s'", err.Error()))
+		}
 
 		return nil
 	})
 
-	if !wasSuccessful {
-		return nil, Error.NewApplicationError(
-			fmt.Sprintf(
-				"Error making request to: '%s', errors: '%s'", uri.String(), multierror.AppendList(errs...).Error(),
-			),
+	if retryErr != nil {
+		/* The caller giving up says nothing about the health of the api, so it's outcome is not recorded. */
+		if err := NewRequestContextError(ctx); err != nil {
+			return nil, err
+		}
+
+		/* Neither does google refusing the key, the call is made again with the next one. */
+		if err := newApiKeyRefusedError(retryErr); err != nil {
+			return nil, err
+		}
+
+		/* Nor the call not being made at all, as it would have exceeded the rate limit. */
+		if err := newRateLimitExceededError(retryErr); err != nil {
+			return nil, err
+		}
+
+		err := Error.NewApplicationError(
+			fmt.Sprintf("Error making request to: '%s', %s", uriStringForLogging, retryErr.Error()),
 		)
+
+		c.status.RecordOutcome(GoogleProvider, err)
+
+		return nil, err
 	}
 
-	resBytes, _ := ioutil.ReadAll(res.Body)
+	c.status.RecordOutcome(GoogleProvider, nil)
 
-	c.logger.Debug(
-		fmt.Sprintf(
-			"Received Streetview api response, status: '%d', bytes: '%d'", res.StatusCode, len(resBytes),
-		),
-	)
+	c.logger.Debug(fmt.Sprintf("Received Streetview api response, bytes: '%d'", len(resBytes)))
+
+	return &StreetViewApiImage{Bytes: resBytes, Provider: GoogleProvider}, nil
+}
+
+/* RequestMetadata performs a request to the (free) metadata endpoint for the location and parameters. */
+func (c *streetViewApiClient) RequestMetadata(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) (*StreetViewMetadata, error) {
+	uri, err := c.buildUrl(locationParameter, c.buildLocationString(latitude, longitude), parameters)
+
+	if err != nil {
+		return nil, err
+	}
 
-	return resBytes, nil
+	return c.requestMetadata(ctx, uri)
 }
 
 /*
@@ -129,23 +321,59 @@ streetviewImageExistsInGoogle performs a metadata endpoint call to check that go
 
 See: https://developers.google.com/maps/documentation/streetview/metadata#response-format
 */
-func (c *streetViewApiClient) streetviewImageExistsInGoogle(uri *url.URL) bool {
-	metadataUri := strings.Replace(uri.String(), "/streetview?", "/streetview/metadata?", 1)
+func (c *streetViewApiClient) streetviewImageExistsInGoogle(ctx context.Context, uri *url.URL) (bool, error) {
+	metadata, err := c.requestMetadata(ctx, uri)
+
+	if err != nil {
+		return false, err
+	}
 
-	uriStringForLogging := regexp.MustCompile(`key=[^&]*`).ReplaceAllString(uri.String(), "${1}")
+	return metadata.Status == MetadataStatusOk, nil
+}
+
+/* requestMetadata performs the request to the metadata endpoint for an image url and parses the response. */
+func (c *streetViewApiClient) requestMetadata(ctx context.Context, uri *url.URL) (*StreetViewMetadata, error) {
+	var metadata *StreetViewMetadata
+
+	err := c.callWithApiKeys(func(key *StreetViewApiKey) (err error) {
+		metadata, err = c.requestMetadataWithApiKey(ctx, uri, key)
+
+		return err
+	})
+
+	return metadata, err
+}
+
+/*
+requestMetadataWithApiKey performs the request to the metadata endpoint for an image url with the api key. Google
+responds to a key that is over it's quota or denied with a metadata status saying so, rather than a http status.
+*/
+func (c *streetViewApiClient) requestMetadataWithApiKey(
+	ctx context.Context, uri *url.URL, key *StreetViewApiKey,
+) (*StreetViewMetadata, error) {
+	metadataUrl := *uri
+	metadataUrl.Path = strings.TrimRight(metadataUrl.Path, "/") + "/metadata"
+	metadataUri := addApiKeyToUrl(metadataUrl, key.Key)
+
+	uriStringForLogging := redactUrl(metadataUri)
+
+	/* The signature covers the path, so the metadata url must be signed separately to the image url. */
+	signedMetadataUri, err := signUrl(metadataUri, key.SigningSecret)
+
+	if err != nil {
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf("Unable to sign url: '%s', error: '%s'", uriStringForLogging, err.Error()),
+		)
+	}
 
 	c.logger.Debug(fmt.Sprintf("Making request for metadata to: %s", uriStringForLogging))
 
-	var res *http.Response
+	var metadata, unknownErrorMetadata *StreetViewMetadata
 
-	defer func() {
-		if res != nil {
-			_ = res.Body.Close()
-		}
-	}()
+	retryErr := c.retrierFactory.Create(ctx, c.config).ExecuteFuncWithRetry(func() error {
+		unknownErrorMetadata = nil
 
-	errs, wasSuccessful := c.retrier.ExecuteFuncWithRetry(func() error {
-		response, err := (&http.Client{Timeout: requestTimeout}).Get(uri.String())
+		response, err := get(ctx, c.httpClient, signedMetadataUri)
 
 		if err != nil {
 			return err
@@ -155,46 +383,166 @@ func (c *streetViewApiClient) streetviewImageExistsInGoogle(uri *url.URL) bool {
 			return errors.New("no response from streetView api")
 		}
 
-		if response.StatusCode != 200 {
-			return errors.New(
-				fmt.Sprintf("response status code: '%d', full response: '%v'", response.StatusCode, res),
-			)
+		c.keys.RecordRequest(key, false)
+
+		if response.StatusCode != http.StatusOK {
+			return newResponseError(response)
+		}
+
+		defer func() { _ = response.Body.Close() }()
+
+		parsed := &StreetViewMetadata{}
+
+		if err := json.NewDecoder(response.Body).Decode(parsed); err != nil {
+			return errors.New(fmt.Sprintf("unable to parse metadata response, error: '%s'", err.Error()))
 		}
 
-		res = response
+		/* Google failing to process the request is the one status that may well be different when requested again. */
+		if parsed.Status == MetadataStatusUnknownError {
+			unknownErrorMetadata = parsed
+
+			return errors.New(fmt.Sprintf("metadata status: '%s'", parsed.Status))
+		}
+
+		metadata = parsed
 
 		return nil
 	})
 
-	if !wasSuccessful {
-		c.logger.Error(
-			fmt.Sprintf(
-				"Error making request to: '%s', errors: '%s'", metadataUri, multierror.AppendList(errs...).Error(),
-			),
+	if retryErr != nil {
+		/* The caller giving up says nothing about the health of the api, so it's outcome is not recorded. */
+		if err := NewRequestContextError(ctx); err != nil {
+			return nil, err
+		}
+
+		/* Neither does the call not being made at all, as it would have exceeded the rate limit. */
+		if err := newRateLimitExceededError(retryErr); err != nil {
+			return nil, err
+		}
+
+		var err error = Error.NewApplicationError(
+			fmt.Sprintf("Error making request to: '%s', %s", uriStringForLogging, retryErr.Error()),
 		)
 
-		return false
+		/* Google failing to process every attempt is reported as such, along with google's reason when it gave one. */
+		if unknownErrorMetadata != nil {
+			err = newMetadataStatusError(unknownErrorMetadata)
+		}
+
+		c.status.RecordOutcome(GoogleProvider, err)
+
+		return nil, err
 	}
 
-	resBytes, _ := ioutil.ReadAll(res.Body)
+	c.status.RecordOutcome(GoogleProvider, nil)
 
-	fmt.Println(string(resBytes))
+	metadata.Provider = GoogleProvider
+
+	c.logger.Debug(fmt.Sprintf("Received Streetview api metadata response, status: '%s'", metadata.Status))
+
+	err = newMetadataStatusError(metadata)
+
+	if metadata.Status == MetadataStatusOverQueryLimit || metadata.Status == MetadataStatusRequestDenied {
+		return nil, &apiKeyRefusedError{
+			reason: fmt.Sprintf("metadata status: '%s', error message: '%s'", metadata.Status, metadata.ErrorMessage),
+			err:    err,
+		}
+	}
 
-	if strings.Contains(string(resBytes), "ZERO_RESULTS") {
-		return false
+	if err != nil {
+		return nil, err
 	}
 
-	return true
+	return metadata, nil
 }
 
-/* addQueryToUrl builds the GET query string from config vars and returns the newly appended url. */
-func (c *streetViewApiClient) addQueryToUrl(url url.URL, latitude float64, longitude float64) *url.URL {
+/*
+callWithApiKeys makes a call with the next api key, and for as long as google refuses the key it is made with (see:
+apiKeyRefusedError) quarantines it and makes the call again with the next one. When the call runs out of keys, why
+google refused the last one is returned as it says more than that there are none left.
+*/
+func (c *streetViewApiClient) callWithApiKeys(call func(key *StreetViewApiKey) error) error {
+	var refusedErr *apiKeyRefusedError
+
+	for {
+		key, err := c.keys.Next()
+
+		if err != nil {
+			if refusedErr != nil {
+				return refusedErr.err
+			}
+
+			return err
+		}
+
+		err = call(key)
+
+		var isRefused bool
+
+		if refusedErr, isRefused = err.(*apiKeyRefusedError); !isRefused {
+			return err
+		}
+
+		c.keys.Quarantine(key, refusedErr.reason)
+	}
+}
+
+/*
+buildUrl builds the full url for a request from the configured endpoint, the parameter identifying the image (a location
+or a pano id) and the image parameters.
+*/
+func (c *streetViewApiClient) buildUrl(
+	locationKey string, locationValue string, parameters *Domain.ImageParameters,
+) (*url.URL, error) {
+	uri, err := url.Parse(c.config.GetEndpoint())
+
+	/* throw new DevRetardationException. */
+	if err != nil {
+		return nil, Error.NewApplicationError(
+			fmt.Sprintf(
+				"Unable to build url for request. Endpoint: '%s', error: '%s'", c.config.GetEndpoint(), err.Error(),
+			),
+		)
+	}
+
+	return c.addQueryToUrl(*uri, locationKey, locationValue, parameters), nil
+}
+
+/*
+addQueryToUrl builds the GET query string from config vars and the provided parameters and returns the newly appended
+url. Parameters that were not provided are left out so that the API defaults are used, except fov and size which have
+config vars. The api key is only added per call, see: addApiKeyToUrl.
+*/
+func (c *streetViewApiClient) addQueryToUrl(
+	url url.URL, locationKey string, locationValue string, parameters *Domain.ImageParameters,
+) *url.URL {
 	q := url.Query()
 
 	queryMap := map[string]string{
-		"size":     c.buildSizeString(),
-		"location": c.buildLocationString(latitude, longitude),
-		"key":      c.config.GetApiKey(),
+		"size":      c.buildSizeString(parameters),
+		locationKey: locationValue,
+		"fov":       strconv.Itoa(c.config.GetFov()),
+		"source":    parameters.GetSource(),
+	}
+
+	if heading := parameters.GetHeading(); heading != nil {
+		queryMap["heading"] = strconv.FormatFloat(*heading, 'f', -1, 64)
+	}
+
+	if pitch := parameters.GetPitch(); pitch != nil {
+		queryMap["pitch"] = strconv.FormatFloat(*pitch, 'f', -1, 64)
+	}
+
+	if fov := parameters.GetFov(); fov != nil {
+		queryMap["fov"] = strconv.Itoa(*fov)
+	}
+
+	if radius := parameters.GetRadius(); radius != nil {
+		queryMap["radius"] = strconv.Itoa(*radius)
+	}
+
+	if scale := parameters.GetScale(); scale != nil {
+		queryMap["scale"] = strconv.Itoa(*scale)
 	}
 
 	for key, value := range queryMap {
@@ -206,16 +554,23 @@ func (c *streetViewApiClient) addQueryToUrl(url url.URL, latitude float64, longi
 	return &url
 }
 
-/* buildSizeString builds the size GET parameter. */
-func (c *streetViewApiClient) buildSizeString() string {
+/*
+buildSizeString builds the size GET parameter, from the parameters when provided otherwise from config. Only the
+configured size is clamped to the max, a requested size must already have been validated against it.
+*/
+func (c *streetViewApiClient) buildSizeString(parameters *Domain.ImageParameters) string {
+	if parameters.GetWidth() != nil && parameters.GetHeight() != nil {
+		return fmt.Sprintf("%dx%d", *parameters.GetWidth(), *parameters.GetHeight())
+	}
+
 	width, height := c.config.GetWidth(), c.config.GetHeight()
 
-	if width > maxWidth {
-		width = maxWidth
+	if width > MaxWidth {
+		width = MaxWidth
 	}
 
-	if height > maxHeight {
-		height = maxHeight
+	if height > MaxHeight {
+		height = MaxHeight
 	}
 
 	return fmt.Sprintf("%dx%d", width, height)
@@ -225,3 +580,13 @@ func (c *streetViewApiClient) buildSizeString() string {
 func (c *streetViewApiClient) buildLocationString(latitude float64, longitude float64) string {
 	return fmt.Sprintf("%f,%f", latitude, longitude)
 }
+
+/* addApiKeyToUrl adds the api key to the GET query string of a built url, returning the url to call. */
+func addApiKeyToUrl(url url.URL, key string) string {
+	q := url.Query()
+	q.Set("key", key)
+
+	url.RawQuery = q.Encode()
+
+	return url.String()
+}
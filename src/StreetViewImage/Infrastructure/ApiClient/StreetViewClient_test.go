@@ -0,0 +1,368 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"app/test/FakeStreetView"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+/* testSigningSecret is the url-safe base64 signing secret of signed requests, "secret" encoded. */
+const testSigningSecret = "c2VjcmV0"
+
+/*
+newTestStreetViewClient returns a client for google's api calling the fake at the endpoint with the keys, configured
+with the signing secret when there is one, along with the keys it rotates between.
+*/
+func newTestStreetViewClient(
+	t *testing.T, endpoint string, apiKeys string, signingSecret string,
+) (*streetViewApiClient, StreetViewApiKeys) {
+	apiConfig := newTestApiConfiguration(t, map[string]string{
+		"STREETVIEW_API_ENDPOINT":       endpoint,
+		"STREETVIEW_API_KEYS":           apiKeys,
+		"STREETVIEW_API_SIGNING_SECRET": signingSecret,
+	})
+
+	keys := NewStreetViewApiKeys(*apiConfig, &Logger.LoggingStrategy{})
+
+	return &streetViewApiClient{
+		config:         apiConfig,
+		retrierFactory: RetrierFactory{},
+		httpClient:     http.DefaultClient,
+		keys:           keys,
+		logger:         Logger.LoggingStrategy{},
+		status:         NewStreetViewApiStatus(),
+	}, keys
+}
+
+/* newTestParameters returns the parameters of a request for an image of the default size. */
+func newTestParameters() *Domain.ImageParameters {
+	return Domain.NewImageParameters(nil, nil, nil, nil, "")
+}
+
+/* assertUserErrorCode fails the test unless the error is a UserError with the code. */
+func assertUserErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	if userErr, isUserErr := err.(Error.UserError); !isUserErr || userErr.Code != code {
+		t.Errorf("Expected a user error with code: '%s', got: '%v'", code, err)
+	}
+}
+
+/* assertApplicationError fails the test unless the error is an ApplicationError containing the message. */
+func assertApplicationError(t *testing.T, err error, message string) {
+	t.Helper()
+
+	if _, isApplicationErr := err.(Error.ApplicationError); !isApplicationErr {
+		t.Fatalf("Expected an application error, got: '%v'", err)
+	}
+
+	if !strings.Contains(err.Error(), message) {
+		t.Errorf("Expected the error to contain: '%s', got: '%s'", message, err.Error())
+	}
+}
+
+func TestStreetViewRequestRetrievesTheImage(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer([]string{"key"}, "")
+	defer server.Close()
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	image, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters())
+
+	if err != nil {
+		t.Fatalf("Expected the image, got error: '%s'", err.Error())
+	}
+
+	if len(image.Bytes) == 0 || image.Provider != GoogleProvider {
+		t.Errorf("Expected an image from: '%s', got: '%d' bytes from: '%s'", GoogleProvider, len(image.Bytes), image.Provider)
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 1 {
+		t.Errorf("Expected coverage to be checked once, got: '%d' metadata requests", count)
+	}
+}
+
+func TestStreetViewRequestMapsZeroResultsToInvalidLocation(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{
+		Endpoint: FakeStreetView.EndpointMetadata, Status: FakeStreetView.StatusZeroResults,
+	})
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	_, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters())
+
+	assertUserErrorCode(t, err, InvalidLocationCode)
+
+	/* There being no image is an answer, so the image is not requested (and paid for). */
+	if count := fake.GetRequestCount(FakeStreetView.EndpointImage); count != 0 {
+		t.Errorf("Expected no image requests, got: '%d'", count)
+	}
+}
+
+func TestStreetViewRequestRetriesABurstOfServerErrors(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{StatusCode: 503, Times: 2})
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	if _, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters()); err != nil {
+		t.Fatalf("Expected the image once the burst was over, got error: '%s'", err.Error())
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 3 {
+		t.Errorf("Expected the metadata to be requested three times, got: '%d'", count)
+	}
+
+	if _, err := client.status.GetLastOutcome(GoogleProvider); err != nil {
+		t.Errorf("Expected the last outcome to be a success, got: '%s'", err.Error())
+	}
+}
+
+func TestStreetViewRequestGivesUpOnAServerErrorThatDoesNotEnd(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{StatusCode: 503})
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	_, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters())
+
+	assertApplicationError(t, err, "Error making request to")
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 3 {
+		t.Errorf("Expected the metadata to be requested the max retries: '3' times, got: '%d'", count)
+	}
+}
+
+func TestStreetViewRequestQuarantinesAndRotatesARefusedKey(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{Endpoint: FakeStreetView.EndpointImage, StatusCode: 403, Times: 1})
+
+	client, keys := newTestStreetViewClient(t, endpoint, "first,second", "")
+
+	if _, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters()); err != nil {
+		t.Fatalf("Expected the image with the other key, got error: '%s'", err.Error())
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointImage); count != 2 {
+		t.Errorf("Expected the image to be requested with each key, got: '%d' requests", count)
+	}
+
+	var refused, quarantined int
+
+	for _, usage := range keys.GetUsage() {
+		refused += usage.Refusals
+
+		if usage.QuarantinedUntil.After(time.Now()) {
+			quarantined++
+		}
+	}
+
+	if refused != 1 || quarantined != 1 {
+		t.Errorf("Expected one key to be refused and quarantined, got: '%+v'", keys.GetUsage())
+	}
+}
+
+func TestStreetViewRequestQuarantinesAKeyTheMetadataDenies(t *testing.T) {
+	_, server, endpoint := FakeStreetView.NewTestServer([]string{"valid"}, "")
+	defer server.Close()
+
+	client, keys := newTestStreetViewClient(t, endpoint, "invalid,valid", "")
+
+	if _, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters()); err != nil {
+		t.Fatalf("Expected the image with the valid key, got error: '%s'", err.Error())
+	}
+
+	if usage := keys.GetUsage(); usage[0].Refusals != 1 || usage[1].Refusals != 0 {
+		t.Errorf("Expected only the invalid key to be refused, got: '%+v'", usage)
+	}
+
+	/* Whilst quarantined, the invalid key is not used again. */
+	if _, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters()); err != nil {
+		t.Fatalf("Expected the image with the valid key again, got error: '%s'", err.Error())
+	}
+
+	if usage := keys.GetUsage(); usage[0].Refusals != 1 || usage[1].Requests != 4 {
+		t.Errorf("Expected every request to be made with the valid key, got: '%+v'", usage)
+	}
+}
+
+func TestStreetViewRequestGivesUpOnceTheDeadlinePasses(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{DelayMillis: 2000})
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := client.Request(ctx, 51.5, -0.1, newTestParameters())
+
+	assertUserErrorCode(t, err, RequestDeadlineExceededCode)
+
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("Expected to give up at the deadline, took: '%s'", took)
+	}
+
+	/* The caller giving up says nothing about the health of the api. */
+	if at, _ := client.status.GetLastOutcome(GoogleProvider); !at.IsZero() {
+		t.Errorf("Expected no outcome to be recorded, got one at: '%s'", at)
+	}
+}
+
+func TestStreetViewRequestRetriesAnImageCutShort(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	whole, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters())
+
+	if err != nil {
+		t.Fatalf("Expected the image, got error: '%s'", err.Error())
+	}
+
+	fake.Script(FakeStreetView.Scenario{Endpoint: FakeStreetView.EndpointImage, DropMillis: 1, Times: 1})
+
+	image, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters())
+
+	if err != nil {
+		t.Fatalf("Expected the image once requested again, got error: '%s'", err.Error())
+	}
+
+	/* Half of a jpeg still starts as one, so only it's length tells that it was cut short. */
+	if len(image.Bytes) != len(whole.Bytes) {
+		t.Errorf("Expected the whole image of: '%d' bytes, got: '%d'", len(whole.Bytes), len(image.Bytes))
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointImage); count != 3 {
+		t.Errorf("Expected the image to be requested again once, got: '%d' image requests", count)
+	}
+}
+
+func TestStreetViewRequestGivesUpOnAnImageOnceTheDeadlinePasses(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	/* The connection is dropped long after the deadline, which passes whilst the image is being read. */
+	fake.Script(FakeStreetView.Scenario{Endpoint: FakeStreetView.EndpointImage, DropMillis: 2000})
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	image, err := client.Request(ctx, 51.5, -0.1, newTestParameters())
+
+	assertUserErrorCode(t, err, RequestDeadlineExceededCode)
+
+	if image != nil {
+		t.Errorf("Expected no image, got: '%d' bytes", len(image.Bytes))
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointImage); count != 1 {
+		t.Errorf("Expected the image to be requested once, got: '%d' image requests", count)
+	}
+}
+
+func TestStreetViewRequestMetadataFailsOnAMalformedBody(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{Endpoint: FakeStreetView.EndpointMetadata, Malformed: true})
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	_, err := client.RequestMetadata(context.Background(), 51.5, -0.1, newTestParameters())
+
+	assertApplicationError(t, err, "unable to parse metadata response")
+
+	/* Nor must the image be requested when whether there is one is unknown. */
+	if _, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters()); err == nil {
+		t.Error("Expected an error, got the image")
+	}
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointImage); count != 0 {
+		t.Errorf("Expected no image requests, got: '%d'", count)
+	}
+}
+
+func TestStreetViewRequestMetadataReportsGoogleFailingEveryAttempt(t *testing.T) {
+	fake, server, endpoint := FakeStreetView.NewTestServer(nil, "")
+	defer server.Close()
+
+	fake.Script(FakeStreetView.Scenario{
+		Endpoint: FakeStreetView.EndpointMetadata, Status: FakeStreetView.StatusUnknownError,
+	})
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", "")
+
+	_, err := client.RequestMetadata(context.Background(), 51.5, -0.1, newTestParameters())
+
+	assertApplicationError(t, err, "Google failed to process the metadata request")
+	assertApplicationError(t, err, "Scripted by a scenario.")
+
+	if count := fake.GetRequestCount(FakeStreetView.EndpointMetadata); count != 3 {
+		t.Errorf("Expected the metadata to be requested the max retries: '3' times, got: '%d'", count)
+	}
+}
+
+func TestStreetViewRequestSignsRequestsWithTheSigningSecret(t *testing.T) {
+	_, server, endpoint := FakeStreetView.NewTestServer(nil, testSigningSecret)
+	defer server.Close()
+
+	client, _ := newTestStreetViewClient(t, endpoint, "key", testSigningSecret)
+
+	if _, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters()); err != nil {
+		t.Fatalf("Expected the signed request to be accepted, got error: '%s'", err.Error())
+	}
+}
+
+func TestStreetViewRequestIsDeniedWhenNotSigned(t *testing.T) {
+	tests := []struct {
+		name          string
+		signingSecret string
+	}{
+		{name: "unsigned", signingSecret: ""},
+		{name: "signed with another secret", signingSecret: "YW5vdGhlcg=="},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake, server, endpoint := FakeStreetView.NewTestServer(nil, testSigningSecret)
+			defer server.Close()
+
+			client, keys := newTestStreetViewClient(t, endpoint, "key", test.signingSecret)
+
+			_, err := client.Request(context.Background(), 51.5, -0.1, newTestParameters())
+
+			assertUserErrorCode(t, err, RequestDeniedCode)
+
+			if usage := keys.GetUsage(); usage[0].Refusals != 1 {
+				t.Errorf("Expected the key to be refused, got: '%+v'", usage)
+			}
+
+			if count := fake.GetRequestCount(FakeStreetView.EndpointImage); count != 0 {
+				t.Errorf("Expected no image requests, got: '%d'", count)
+			}
+		})
+	}
+}
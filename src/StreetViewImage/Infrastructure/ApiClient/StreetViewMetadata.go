@@ -0,0 +1,92 @@
+package ApiClient
+
+import (
+	"app/src/StreetViewImage/Application/Error"
+	"fmt"
+)
+
+/* MetadataStatus is the status of a metadata response, saying whether an image is available or why not. */
+type MetadataStatus string
+
+/* Metadata statuses, see: https://developers.google.com/maps/documentation/streetview/metadata#status-codes. */
+const (
+	MetadataStatusOk          MetadataStatus = "OK"
+	MetadataStatusZeroResults MetadataStatus = "ZERO_RESULTS"
+	MetadataStatusNotFound    MetadataStatus = "NOT_FOUND"
+
+	/* The statuses of a call google refused because of the api key it was made with, see: StreetViewApiKeys. */
+	MetadataStatusOverQueryLimit MetadataStatus = "OVER_QUERY_LIMIT"
+	MetadataStatusRequestDenied  MetadataStatus = "REQUEST_DENIED"
+
+	MetadataStatusInvalidRequest MetadataStatus = "INVALID_REQUEST"
+	MetadataStatusUnknownError   MetadataStatus = "UNKNOWN_ERROR"
+)
+
+/* Error constants, for the metadata statuses that are neither an image nor the lack of one. */
+const (
+	LocationNotFoundCode = "LocationNotFoundCode"
+	LocationNotFoundErr  = "location not found: the street view api could not find the location requested"
+	QuotaExceededCode    = "QuotaExceededCode"
+	QuotaExceededErr     = "the street view api quota is exceeded, please retry the request later"
+	RequestDeniedCode    = "RequestDeniedCode"
+	RequestDeniedErr     = "the street view api denied the request, the service is misconfigured"
+	InvalidRequestCode   = "InvalidRequestCode"
+	InvalidRequestErr    = "the street view api rejected the request as invalid, check the parameters provided"
+)
+
+/*
+StreetViewMetadata is the response of the metadata endpoint, describing the panorama an image request would return.
+
+See: https://developers.google.com/maps/documentation/streetview/metadata#response-format
+*/
+type StreetViewMetadata struct {
+	/* Status is one of the metadata statuses, only OK means that an image is available. */
+	Status MetadataStatus `json:"status"`
+	/* PanoId is the unique identifier of the panorama. */
+	PanoId string `json:"pano_id"`
+	/* Date is the year and month the panorama was captured, in the format "2019-05". */
+	Date string `json:"date"`
+	/* Copyright is the copyright notice that must be displayed with the image. */
+	Copyright string `json:"copyright"`
+	/* Location is where the panorama actually is, snapped from the requested location. */
+	Location MetadataLocation `json:"location"`
+	/* ErrorMessage explains a status other than OK or ZERO_RESULTS, when google gives a reason. */
+	ErrorMessage string `json:"error_message"`
+	/* Provider is the imagery provider that described the panorama, set by the client rather than the response. */
+	Provider string `json:"-"`
+}
+
+/* MetadataLocation is the location of a panorama as returned by the metadata endpoint. */
+type MetadataLocation struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+}
+
+/*
+newMetadataStatusError returns the error a metadata status stands for, nil for OK and ZERO_RESULTS as both are an answer
+to whether there is an image. Google being unable to find the location, refusing the request or finding it invalid are
+UserErrors, though only the last is the user's fault, whereas a status that is unknown, or google failing to process
+the request, is an ApplicationError.
+*/
+func newMetadataStatusError(metadata *StreetViewMetadata) error {
+	switch metadata.Status {
+	case MetadataStatusOk, MetadataStatusZeroResults:
+		return nil
+	case MetadataStatusNotFound:
+		return Error.UserError{Code: LocationNotFoundCode, Err: LocationNotFoundErr}
+	case MetadataStatusOverQueryLimit:
+		return Error.UserError{Code: QuotaExceededCode, Err: QuotaExceededErr}
+	case MetadataStatusRequestDenied:
+		return Error.UserError{Code: RequestDeniedCode, Err: RequestDeniedErr}
+	case MetadataStatusInvalidRequest:
+		return Error.UserError{Code: InvalidRequestCode, Err: InvalidRequestErr}
+	case MetadataStatusUnknownError:
+		return Error.NewApplicationError(fmt.Sprintf(
+			"Google failed to process the metadata request, error message: '%s'", metadata.ErrorMessage,
+		))
+	}
+
+	return Error.NewApplicationError(fmt.Sprintf(
+		"Unexpected metadata status: '%s', error message: '%s'", metadata.Status, metadata.ErrorMessage,
+	))
+}
@@ -0,0 +1,52 @@
+package ApiClient
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+/* redactionRegex matches the query parameters that must never be logged: the api key and the url signature. */
+var redactionRegex = regexp.MustCompile(`(key|signature|access_token)=[^&]*`)
+
+/*
+signUrl appends the digital signature Google requires above certain volumes to the url: a HMAC-SHA1 of the path and
+query, keyed with the url-safe base64 decoded signing secret and url-safe base64 encoded itself.
+
+An empty secret means signing is not configured so the url is returned as is.
+
+See: https://developers.google.com/maps/documentation/streetview/get-api-key#digital-signature
+*/
+func signUrl(rawUrl string, secret string) (string, error) {
+	if secret == "" {
+		return rawUrl, nil
+	}
+
+	uri, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return "", err
+	}
+
+	decodedSecret, err := base64.URLEncoding.DecodeString(secret)
+
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("signing secret is not url-safe base64, error: '%s'", err.Error()))
+	}
+
+	mac := hmac.New(sha1.New, decodedSecret)
+	_, _ = mac.Write([]byte(uri.EscapedPath() + "?" + uri.RawQuery))
+
+	signature := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+
+	return fmt.Sprintf("%s&signature=%s", rawUrl, signature), nil
+}
+
+/* redactUrl removes the key and signature from the url so it can be logged without sharing anything dangerous. */
+func redactUrl(rawUrl string) string {
+	return redactionRegex.ReplaceAllString(rawUrl, "")
+}
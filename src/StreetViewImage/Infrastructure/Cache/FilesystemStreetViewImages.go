@@ -0,0 +1,571 @@
+package Cache
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	/* tempFilePrefix prefixes the files images are written to before being renamed into place. */
+	tempFilePrefix = ".tmp-"
+
+	/* shardLength is the number of hex characters of the hashed key naming each of the two levels of directories. */
+	shardLength = 2
+
+	/* directoryPermissions and filePermissions are those of the directories and files created. */
+	directoryPermissions = 0755
+	filePermissions      = 0644
+)
+
+/*
+diskMetadata is stored as a single line of JSON at the start of every file, before the bytes of the image, so that the
+index can be recovered from the files alone and an image expires when it was meant to whenever the instance restarts.
+*/
+type diskMetadata struct {
+	Key       string    `json:"key"`
+	Provider  string    `json:"provider,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+/*
+FilesystemStreetViewImages is a Repository persisting images to the local disk of the instance, for nodes that have no
+redis. Each image is stored in it's own file, in two levels of directories named from the hash of it's uuid so that no
+directory grows too large, and is written to a temporary file first then renamed into place so that a partially written
+image is never read.
+
+The files are indexed in memory, the index being recovered from the files on disk once opened (see: Open), and a
+janitor removes the expired files in the background along with the least recently used ones beyond the max disk usage.
+*/
+type FilesystemStreetViewImages struct {
+	mutex  sync.Mutex
+	logger *Logger.LoggingStrategy
+
+	directory            string
+	maxBytes             int64
+	expiration           time.Duration
+	noCoverageExpiration time.Duration
+	janitorInterval      time.Duration
+
+	/* entries indexes the files on disk by their key, bytes being the size of them all. */
+	entries map[string]*diskEntry
+	bytes   int64
+
+	/* janitorSignal wakes the janitor early when the max disk usage is exceeded. */
+	janitorSignal chan struct{}
+}
+
+/*
+diskEntry is the index of a single file on disk. Every write indexes a new entry, and only it's accessedAt changes once
+indexed, so the rest of it can be read without the lock.
+*/
+type diskEntry struct {
+	path       string
+	size       int64
+	expiresAt  time.Time
+	accessedAt time.Time
+}
+
+/* NewFilesystemStreetViewImages returns a new FilesystemStreetViewImages, which must be opened before it is used. */
+func NewFilesystemStreetViewImages(
+	config config.CacheConfiguration, logger *Logger.LoggingStrategy,
+) *FilesystemStreetViewImages {
+	janitorInterval := config.GetDiskJanitorInterval()
+
+	if janitorInterval < 1 {
+		janitorInterval = 1
+	}
+
+	return &FilesystemStreetViewImages{
+		logger:               logger,
+		directory:            filepath.Clean(config.GetDiskDirectory()),
+		maxBytes:             int64(config.GetDiskMaxBytes()),
+		expiration:           time.Duration(config.GetDiskExpiration()) * time.Second,
+		noCoverageExpiration: time.Duration(config.GetDiskNoCoverageExpiration()) * time.Second,
+		janitorInterval:      time.Duration(janitorInterval) * time.Second,
+		entries:              make(map[string]*diskEntry),
+		janitorSignal:        make(chan struct{}, 1),
+	}
+}
+
+/*
+Open creates the directory if need be and recovers the index from the files in it, then starts the janitor in the
+background. Files left over by a write that was interrupted, unreadable files and expired files are removed.
+*/
+func (i *FilesystemStreetViewImages) Open() error {
+	if err := os.MkdirAll(i.directory, directoryPermissions); err != nil {
+		return errors.New(fmt.Sprintf("unable to create image directory: '%s', error: '%s'", i.directory, err.Error()))
+	}
+
+	if err := i.recoverIndex(); err != nil {
+		return errors.New(fmt.Sprintf("unable to recover image index from: '%s', error: '%s'", i.directory, err.Error()))
+	}
+
+	i.logger.Info(fmt.Sprintf(
+		"Recovered: '%d' files with byte length: '%d' from directory: '%s'", len(i.entries), i.bytes, i.directory,
+	))
+
+	i.clean()
+
+	go func() {
+		ticker := time.NewTicker(i.janitorInterval)
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-i.janitorSignal:
+			}
+
+			i.clean()
+		}
+	}()
+
+	return nil
+}
+
+/* Save writes the image to disk and returns whether or not this writing was successful. */
+func (i *FilesystemStreetViewImages) Save(ctx context.Context, image Domain.StreetViewImage) bool {
+	metadata := diskMetadata{Key: image.GetUuid(), Provider: image.GetProvider(), CreatedAt: image.GetCreatedAt()}
+
+	return i.write(metadata, image.GetBytes(), i.expiration)
+}
+
+/* Find retrieves an image from disk if one exists. */
+func (i *FilesystemStreetViewImages) Find(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(Domain.NewImageUuid(latitude, longitude, parameters))
+}
+
+/* FindByPanoId retrieves an image requested by it's panorama id from disk if one exists. */
+func (i *FilesystemStreetViewImages) FindByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(Domain.NewPanoImageUuid(panoId, parameters))
+}
+
+/* FindPanorama retrieves a stitched panorama of a location from disk if one exists. */
+func (i *FilesystemStreetViewImages) FindPanorama(
+	ctx context.Context, latitude float64, longitude float64, slices int, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(Domain.NewPanoramaImageUuid(latitude, longitude, slices, parameters))
+}
+
+/* FindMany retrieves multiple images from disk, nil for those not found. */
+func (i *FilesystemStreetViewImages) FindMany(ctx context.Context, uuids []*Domain.ImageUuid) []Domain.StreetViewImage {
+	images := make([]Domain.StreetViewImage, len(uuids))
+
+	for index, imageUuid := range uuids {
+		images[index] = i.findByUuid(imageUuid)
+	}
+
+	return images
+}
+
+/*
+SaveNoCoverage records that there is no image for the location (or panorama) of the uuid, for the configured time as
+opposed to the time images are stored for, as coverage gets added.
+*/
+func (i *FilesystemStreetViewImages) SaveNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	metadata := diskMetadata{Key: i.formatNoCoverageKey(imageUuid), CreatedAt: time.Now()}
+
+	return i.write(metadata, nil, i.noCoverageExpiration)
+}
+
+/* HasNoCoverage retrieves whether it is known that there is no image for the location (or panorama) of the uuid. */
+func (i *FilesystemStreetViewImages) HasNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	return i.retrieve(i.formatNoCoverageKey(imageUuid)) != nil
+}
+
+/* findByUuid retrieves the image stored under the given uuid from disk if one exists. */
+func (i *FilesystemStreetViewImages) findByUuid(imageUuid *Domain.ImageUuid) Domain.StreetViewImage {
+	key := imageUuid.String()
+
+	entry := i.retrieve(key)
+
+	if entry == nil {
+		return nil
+	}
+
+	metadata, imageBytes, err := i.read(entry.path)
+
+	if err == nil && metadata.Key != key {
+		err = errors.New(fmt.Sprintf("file is of key: '%s'", metadata.Key))
+	}
+
+	if err != nil {
+		i.logger.Warning(fmt.Sprintf("Image file: '%s' read from disk invalid, reason: '%s'", entry.path, err.Error()))
+
+		i.forget(key, entry)
+
+		return nil
+	}
+
+	image, err := Domain.NewStoredStreetViewImage(imageUuid, imageBytes, metadata.CreatedAt, metadata.Provider)
+
+	if err != nil {
+		i.logger.Warning(fmt.Sprintf("Image bytes read from disk invalid, reason: '%s'", err.Error()))
+
+		i.forget(key, entry)
+
+		return nil
+	}
+
+	return image
+}
+
+/*
+retrieve retrieves the entry of the file stored under the key, marking it as the most recently used, or nil when none
+is stored. An expired file is removed instead.
+*/
+func (i *FilesystemStreetViewImages) retrieve(key string) *diskEntry {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	entry, isIndexed := i.entries[key]
+
+	if !isIndexed {
+		return nil
+	}
+
+	now := time.Now()
+
+	if !now.Before(entry.expiresAt) {
+		i.remove(key)
+
+		return nil
+	}
+
+	entry.accessedAt = now
+
+	return entry
+}
+
+/*
+write writes the metadata and the bytes to a temporary file in the directory of the key, then renames it into place,
+replacing any file stored under the key already. The janitor is woken when the max disk usage is then exceeded.
+
+The file expires the ttl after it's created at, so that an image promoted from another tier keeps the lifetime it was
+retrieved with, and nothing is written once that has passed.
+*/
+func (i *FilesystemStreetViewImages) write(metadata diskMetadata, imageBytes []byte, ttl time.Duration) bool {
+	metadata.ExpiresAt = metadata.CreatedAt.Add(ttl)
+
+	if !metadata.ExpiresAt.After(time.Now()) {
+		i.logger.Debug(fmt.Sprintf("Not storing key: '%s' on disk, it's lifetime has passed", metadata.Key))
+
+		return false
+	}
+
+	path := i.formatPath(metadata.Key)
+
+	header, err := json.Marshal(metadata)
+
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), directoryPermissions)
+	}
+
+	var file *os.File
+
+	if err == nil {
+		file, err = ioutil.TempFile(filepath.Dir(path), tempFilePrefix)
+	}
+
+	if err != nil {
+		i.logger.Warning(fmt.Sprintf("Could not store key: '%s' on disk, reason: '%s'", metadata.Key, err.Error()))
+
+		return false
+	}
+
+	size := int64(len(header) + 1 + len(imageBytes))
+
+	_, err = file.Write(append(append(header, '\n'), imageBytes...))
+
+	if err == nil {
+		err = file.Chmod(filePermissions)
+	}
+
+	/* Otherwise the rename may be persisted before the bytes are, leaving a truncated file after a crash. */
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = i.replace(metadata, path, file.Name(), size)
+	}
+
+	if err != nil {
+		_ = os.Remove(file.Name())
+
+		i.logger.Warning(fmt.Sprintf("Could not store key: '%s' on disk, reason: '%s'", metadata.Key, err.Error()))
+
+		return false
+	}
+
+	/* Otherwise the rename itself may not be persisted, the file stored being the one before it after a crash. */
+	if err := i.syncDirectory(filepath.Dir(path)); err != nil {
+		i.logger.Warning(fmt.Sprintf("Could not sync directory of key: '%s', reason: '%s'", metadata.Key, err.Error()))
+	}
+
+	i.logger.Debug(fmt.Sprintf("Stored key: '%s' on disk with byte length: '%d'", metadata.Key, size))
+
+	return true
+}
+
+/* replace renames the temporary file into place and indexes it, waking the janitor if the disk is now over usage. */
+func (i *FilesystemStreetViewImages) replace(metadata diskMetadata, path string, tempPath string, size int64) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return err
+	}
+
+	if entry, isIndexed := i.entries[metadata.Key]; isIndexed {
+		i.bytes -= entry.size
+	}
+
+	i.entries[metadata.Key] = &diskEntry{path: path, size: size, expiresAt: metadata.ExpiresAt, accessedAt: time.Now()}
+	i.bytes += size
+
+	if i.bytes > i.maxBytes {
+		select {
+		case i.janitorSignal <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+/* syncDirectory flushes the entries of the directory at the path, such as a file renamed into it, to disk. */
+func (i *FilesystemStreetViewImages) syncDirectory(path string) error {
+	directory, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	err = directory.Sync()
+
+	if closeErr := directory.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+/* read reads the metadata and the bytes of the file at the path. */
+func (i *FilesystemStreetViewImages) read(path string) (*diskMetadata, []byte, error) {
+	contents, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headerLength := bytes.IndexByte(contents, '\n')
+
+	if headerLength < 0 {
+		return nil, nil, errors.New("file has no metadata")
+	}
+
+	metadata := &diskMetadata{}
+
+	if err := json.Unmarshal(contents[:headerLength], metadata); err != nil {
+		return nil, nil, err
+	}
+
+	return metadata, contents[headerLength+1:], nil
+}
+
+/* readMetadata reads the metadata at the start of the file at the path, without the bytes following it. */
+func (i *FilesystemStreetViewImages) readMetadata(path string) (*diskMetadata, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	header, err := bufio.NewReader(file).ReadBytes('\n')
+
+	if err != nil {
+		return nil, errors.New("file has no metadata")
+	}
+
+	metadata := &diskMetadata{}
+
+	if err := json.Unmarshal(header, metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+/*
+recoverIndex indexes every file in the directory from it's metadata, as recently used as it was last written. Files
+that are not where their key would be stored are removed along with any others that cannot be indexed.
+*/
+func (i *FilesystemStreetViewImages) recoverIndex() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return filepath.Walk(i.directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		if strings.HasPrefix(info.Name(), tempFilePrefix) {
+			i.removeFile(path)
+
+			return nil
+		}
+
+		metadata, err := i.readMetadata(path)
+
+		if err != nil || i.formatPath(metadata.Key) != path {
+			i.logger.Warning(fmt.Sprintf("Removing file: '%s' from disk, as it is not a stored image", path))
+
+			i.removeFile(path)
+
+			return nil
+		}
+
+		if !time.Now().Before(metadata.ExpiresAt) {
+			i.removeFile(path)
+
+			return nil
+		}
+
+		i.entries[metadata.Key] = &diskEntry{
+			path: path, size: info.Size(), expiresAt: metadata.ExpiresAt, accessedAt: info.ModTime(),
+		}
+		i.bytes += info.Size()
+
+		return nil
+	})
+}
+
+/*
+clean removes every expired file, then the least recently used files until the disk usage is within the max. This is
+the janitor's job, and holds the lock throughout so that no file is written in place of one whilst it is removed.
+*/
+func (i *FilesystemStreetViewImages) clean() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	now := time.Now()
+
+	keys := make([]string, 0, len(i.entries))
+
+	expired := 0
+
+	for key, entry := range i.entries {
+		if !now.Before(entry.expiresAt) {
+			i.remove(key)
+
+			expired++
+
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	evicted := 0
+
+	if i.bytes > i.maxBytes {
+		sort.Slice(keys, func(a int, b int) bool {
+			return i.entries[keys[a]].accessedAt.Before(i.entries[keys[b]].accessedAt)
+		})
+
+		for _, key := range keys {
+			if i.bytes <= i.maxBytes {
+				break
+			}
+
+			i.remove(key)
+
+			evicted++
+		}
+	}
+
+	if expired > 0 || evicted > 0 {
+		i.logger.Debug(fmt.Sprintf(
+			"Removed: '%d' expired and: '%d' least recently used files from disk, byte length now: '%d'",
+			expired, evicted, i.bytes,
+		))
+	}
+}
+
+/*
+forget removes the file of the entry stored under the key, as it cannot be read, unless it has been replaced since it
+was retrieved: the file read may well have been the one before it, or removed by the janitor in the meantime.
+*/
+func (i *FilesystemStreetViewImages) forget(key string, entry *diskEntry) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.entries[key] == entry {
+		i.remove(key)
+	}
+}
+
+/* remove removes the file stored under the key from disk and the index. Requires the lock held. */
+func (i *FilesystemStreetViewImages) remove(key string) {
+	entry, isIndexed := i.entries[key]
+
+	if !isIndexed {
+		return
+	}
+
+	delete(i.entries, key)
+
+	i.bytes -= entry.size
+
+	i.removeFile(entry.path)
+}
+
+/* removeFile removes the file at the path, logging any error as there is nothing else to be done about it. */
+func (i *FilesystemStreetViewImages) removeFile(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		i.logger.Warning(fmt.Sprintf("Could not remove file: '%s' from disk, reason: '%s'", path, err.Error()))
+	}
+}
+
+/*
+formatPath formats the path of the file stored under the key: the hex of it's hash, in directories named from the first
+and the next characters of it. For example: '<directory>/3f/a2/3fa2...'.
+*/
+func (i *FilesystemStreetViewImages) formatPath(key string) string {
+	hash := sha1.Sum([]byte(key))
+	name := hex.EncodeToString(hash[:])
+
+	return filepath.Join(i.directory, name[:shardLength], name[shardLength:shardLength*2], name)
+}
+
+/* formatNoCoverageKey formats the key recording that the location (or panorama) of the uuid has no image. */
+func (i *FilesystemStreetViewImages) formatNoCoverageKey(imageUuid *Domain.ImageUuid) string {
+	return fmt.Sprintf("%s:%s", noCoverageKeyPrefix, imageUuid.GetCoverageUuid().String())
+}
@@ -0,0 +1,270 @@
+package Cache
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/j7mbo/goenvconfig"
+)
+
+/* newTestCacheConfiguration returns a cache configuration parsed from the environment, with the variables given set. */
+func newTestCacheConfiguration(t *testing.T, variables map[string]string) config.CacheConfiguration {
+	cacheConfig := config.CacheConfiguration{}
+
+	for name, value := range variables {
+		t.Setenv(name, value)
+	}
+
+	if err := goenvconfig.NewGoEnvParser().Parse(&cacheConfig); err != nil {
+		t.Fatalf("Unable to parse the test configuration, error: '%s'", err.Error())
+	}
+
+	return cacheConfig
+}
+
+/*
+newTestImage returns an image of the location with the size in bytes, created at the time. The bytes start as a jpeg
+does, which is all it takes to be a valid image.
+*/
+func newTestImage(t *testing.T, latitude float64, size int, createdAt time.Time) Domain.StreetViewImage {
+	imageBytes := append([]byte{0xFF, 0xD8, 0xFF}, bytes.Repeat([]byte{byte(latitude)}, size-3)...)
+
+	imageUuid := Domain.NewImageUuid(latitude, 0, Domain.NewImageParameters(nil, nil, nil, nil, ""))
+
+	image, err := Domain.NewStoredStreetViewImage(imageUuid, imageBytes, createdAt, "test")
+
+	if err != nil {
+		t.Fatalf("Unable to create the test image, error: '%s'", err.Error())
+	}
+
+	return image
+}
+
+/* newTestFilesystemImages returns opened FilesystemStreetViewImages in the directory, with the variables given set. */
+func newTestFilesystemImages(
+	t *testing.T, directory string, variables map[string]string,
+) *FilesystemStreetViewImages {
+	variables["CACHE_DISK_DIRECTORY"] = directory
+
+	images := NewFilesystemStreetViewImages(newTestCacheConfiguration(t, variables), &Logger.LoggingStrategy{})
+
+	if err := images.Open(); err != nil {
+		t.Fatalf("Unable to open the images, error: '%s'", err.Error())
+	}
+
+	return images
+}
+
+/* findTestImage finds the image of the location in the images, nil when there is none. */
+func findTestImage(images Domain.StreetViewImages, latitude float64) Domain.StreetViewImage {
+	return images.Find(context.Background(), latitude, 0, Domain.NewImageParameters(nil, nil, nil, nil, ""))
+}
+
+/* writeTestFile writes the contents to the path, creating it's directories. */
+func writeTestFile(t *testing.T, path string, contents []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), directoryPermissions); err != nil {
+		t.Fatalf("Unable to create the directory of: '%s', error: '%s'", path, err.Error())
+	}
+
+	if err := ioutil.WriteFile(path, contents, filePermissions); err != nil {
+		t.Fatalf("Unable to write: '%s', error: '%s'", path, err.Error())
+	}
+}
+
+/* writeTestMetadata writes the metadata and the bytes to the path, as a stored image is written. */
+func writeTestMetadata(t *testing.T, path string, metadata diskMetadata, imageBytes []byte) {
+	header, _ := json.Marshal(metadata)
+
+	writeTestFile(t, path, append(append(header, '\n'), imageBytes...))
+}
+
+/* assertFileExists fails the test unless a file exists (or not) at the path. */
+func assertFileExists(t *testing.T, path string, exists bool) {
+	t.Helper()
+
+	if _, err := os.Stat(path); (err == nil) != exists {
+		t.Errorf("Expected file: '%s' to exist: '%t', got error: '%v'", path, exists, err)
+	}
+}
+
+func TestFilesystemImagesAreRecoveredWhenReopened(t *testing.T) {
+	directory := t.TempDir()
+	createdAt := time.Now().Add(-time.Hour)
+
+	images := newTestFilesystemImages(t, directory, map[string]string{})
+
+	if !images.Save(context.Background(), newTestImage(t, 1, 100, createdAt)) {
+		t.Fatal("Expected the image to be saved")
+	}
+
+	reopened := newTestFilesystemImages(t, directory, map[string]string{})
+
+	image := findTestImage(reopened, 1)
+
+	if image == nil {
+		t.Fatal("Expected the image to be recovered, got none")
+	}
+
+	isSaved := len(image.GetBytes()) == 100 && image.GetProvider() == "test"
+
+	if !isSaved || !image.GetCreatedAt().Equal(createdAt.Truncate(time.Second)) {
+		t.Errorf("Expected the image as it was saved, got: '%d' bytes from: '%s' created at: '%s'",
+			len(image.GetBytes()), image.GetProvider(), image.GetCreatedAt())
+	}
+
+	if reopened.bytes != images.bytes {
+		t.Errorf("Expected the recovered byte length: '%d', got: '%d'", images.bytes, reopened.bytes)
+	}
+}
+
+func TestFilesystemRecoverIndexRemovesFilesThatAreNotStoredImages(t *testing.T) {
+	directory := t.TempDir()
+	images := newTestFilesystemImages(t, directory, map[string]string{})
+
+	key := newTestImage(t, 1, 100, time.Now()).GetUuid()
+	path := images.formatPath(key)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		write func(t *testing.T) string
+	}{
+		{name: "interrupted write", write: func(t *testing.T) string {
+			tempPath := filepath.Join(filepath.Dir(path), tempFilePrefix+"123")
+			writeTestMetadata(t, tempPath, diskMetadata{Key: key, ExpiresAt: future}, []byte{0xFF, 0xD8, 0xFF})
+
+			return tempPath
+		}},
+		{name: "no metadata", write: func(t *testing.T) string {
+			writeTestFile(t, path, []byte("not an image"))
+
+			return path
+		}},
+		{name: "stored under another key", write: func(t *testing.T) string {
+			otherPath := images.formatPath("another key")
+			writeTestMetadata(t, otherPath, diskMetadata{Key: key, ExpiresAt: future}, []byte{0xFF, 0xD8, 0xFF})
+
+			return otherPath
+		}},
+		{name: "expired", write: func(t *testing.T) string {
+			writeTestMetadata(t, path, diskMetadata{Key: key, ExpiresAt: time.Now()}, []byte{0xFF, 0xD8, 0xFF})
+
+			return path
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			written := test.write(t)
+
+			reopened := newTestFilesystemImages(t, directory, map[string]string{})
+
+			assertFileExists(t, written, false)
+
+			if len(reopened.entries) != 0 || reopened.bytes != 0 {
+				t.Errorf("Expected nothing to be indexed, got: '%d' entries of byte length: '%d'",
+					len(reopened.entries), reopened.bytes)
+			}
+		})
+	}
+}
+
+func TestFilesystemImageExpiresWhenItsStoredMetadataSays(t *testing.T) {
+	directory := t.TempDir()
+	createdAt := time.Now().Truncate(time.Second).Add(-time.Minute)
+
+	images := newTestFilesystemImages(t, directory, map[string]string{"CACHE_DISK_EXPIRATION": "3600"})
+	image := newTestImage(t, 1, 100, createdAt)
+
+	images.Save(context.Background(), image)
+
+	/* The image expires when it was meant to, however long images are stored for once reopened. */
+	reopened := newTestFilesystemImages(t, directory, map[string]string{"CACHE_DISK_EXPIRATION": "60000"})
+
+	entry := reopened.entries[image.GetUuid()]
+
+	if entry == nil || !entry.expiresAt.Equal(createdAt.Add(time.Hour)) {
+		t.Fatalf("Expected the image to expire at: '%s', got: '%+v'", createdAt.Add(time.Hour), entry)
+	}
+
+	entry.expiresAt = time.Now()
+
+	if findTestImage(reopened, 1) != nil {
+		t.Error("Expected the expired image not to be found")
+	}
+
+	assertFileExists(t, entry.path, false)
+
+	/* Nor is an image stored once it's lifetime has passed, such as one promoted from another tier. */
+	if reopened.Save(context.Background(), newTestImage(t, 2, 100, time.Now().Add(-1000*time.Hour))) {
+		t.Error("Expected an image past it's lifetime not to be saved")
+	}
+}
+
+func TestFilesystemJanitorRemovesTheLeastRecentlyUsedBeyondTheMaxBytes(t *testing.T) {
+	images := newTestFilesystemImages(t, t.TempDir(), map[string]string{})
+
+	for _, latitude := range []float64{1, 2, 3} {
+		images.Save(context.Background(), newTestImage(t, latitude, 100, time.Now()))
+	}
+
+	findTestImage(images, 1)
+	findTestImage(images, 3)
+
+	/* Just too small for the three, so the least recently used alone is removed. */
+	images.mutex.Lock()
+	images.maxBytes = images.bytes - 1
+	images.mutex.Unlock()
+
+	images.clean()
+
+	for latitude, expected := range map[float64]bool{1: true, 2: false, 3: true} {
+		if found := findTestImage(images, latitude) != nil; found != expected {
+			t.Errorf("Expected the image: '%v' to be found: '%t', got: '%t'", latitude, expected, found)
+		}
+	}
+
+	if images.bytes > images.maxBytes {
+		t.Errorf("Expected the byte length to be within: '%d', got: '%d'", images.maxBytes, images.bytes)
+	}
+}
+
+func TestFilesystemForgetDoesNotRemoveAReplacement(t *testing.T) {
+	images := newTestFilesystemImages(t, t.TempDir(), map[string]string{})
+
+	image := newTestImage(t, 1, 100, time.Now())
+	images.Save(context.Background(), image)
+
+	read := images.retrieve(image.GetUuid())
+
+	/* Replaced after the file was read, such as by a request that fetched the image again in the meantime. */
+	images.Save(context.Background(), newTestImage(t, 1, 200, time.Now()))
+
+	images.forget(image.GetUuid(), read)
+
+	if found := findTestImage(images, 1); found == nil || len(found.GetBytes()) != 200 {
+		t.Fatalf("Expected the replacement to be kept, got: '%v'", found)
+	}
+
+	/* The file that is read being unreadable is forgotten though. */
+	writeTestFile(t, images.formatPath(image.GetUuid()), []byte("not an image"))
+
+	if findTestImage(images, 1) != nil {
+		t.Error("Expected the unreadable image not to be found")
+	}
+
+	if len(images.entries) != 0 {
+		t.Errorf("Expected the unreadable image to be forgotten, got: '%d' entries", len(images.entries))
+	}
+
+	assertFileExists(t, images.formatPath(image.GetUuid()), false)
+}
@@ -0,0 +1,259 @@
+package Cache
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	/* The stores images can be cached in, see: config.CacheConfiguration. */
+	StoreRedis  = "redis"
+	StoreMemory = "memory"
+	StoreDisk   = "disk"
+)
+
+/* MemoryCacheStats are the counters of a MemoryStreetViewImages since the instance started. */
+type MemoryCacheStats struct {
+	/* Hits and Misses count the images found and not found, FindMany counting each of the images separately. */
+	Hits   int
+	Misses int
+	/* Evictions counts the least recently used entries removed to make room, Expirations those removed once expired. */
+	Evictions   int
+	Expirations int
+	/* Entries is the number of images and locations without coverage held, Bytes the size they are accounted for. */
+	Entries int
+	Bytes   int
+}
+
+/*
+MemoryStreetViewImages is a Repository holding images in the memory of the instance, for single instance deployments
+and for when redis is unavailable. The images held are bounded by their size in bytes, the least recently used ones
+being evicted to make room for new ones, and each one expires after the configured time. It is safe for concurrent
+use.
+*/
+type MemoryStreetViewImages struct {
+	mutex  sync.Mutex
+	logger *Logger.LoggingStrategy
+
+	maxBytes             int
+	expiration           time.Duration
+	noCoverageExpiration time.Duration
+
+	/* entries are ordered from the most to the least recently used, and indexed by their key in elements. */
+	entries  *list.List
+	elements map[string]*list.Element
+	bytes    int
+
+	hits        int
+	misses      int
+	evictions   int
+	expirations int
+}
+
+/* memoryEntry is an image, or the record of a location without coverage when the image is nil, held in memory. */
+type memoryEntry struct {
+	key       string
+	image     Domain.StreetViewImage
+	size      int
+	expiresAt time.Time
+}
+
+/* NewMemoryStreetViewImages returns a new, empty, MemoryStreetViewImages. */
+func NewMemoryStreetViewImages(
+	config config.CacheConfiguration, logger *Logger.LoggingStrategy,
+) *MemoryStreetViewImages {
+	return &MemoryStreetViewImages{
+		logger:               logger,
+		maxBytes:             config.GetMemoryMaxBytes(),
+		expiration:           time.Duration(config.GetMemoryExpiration()) * time.Second,
+		noCoverageExpiration: time.Duration(config.GetMemoryNoCoverageExpiration()) * time.Second,
+		entries:              list.New(),
+		elements:             make(map[string]*list.Element),
+	}
+}
+
+/*
+Save holds the image in memory, evicting the least recently used images to make room for it, and returns whether or
+not this holding was successful: an image larger than the memory allowed in total is not held.
+
+The image expires the configured time after it's created at, so that an image promoted from another tier keeps the
+lifetime it was retrieved with, and is not held once that has passed.
+*/
+func (i *MemoryStreetViewImages) Save(ctx context.Context, image Domain.StreetViewImage) bool {
+	ttl := time.Until(image.GetCreatedAt().Add(i.expiration))
+
+	if ttl <= 0 {
+		i.logger.Debug(fmt.Sprintf("Not holding key: '%s' in memory, it's lifetime has passed", image.GetUuid()))
+
+		return false
+	}
+
+	return i.store(image.GetUuid(), image, len(image.GetBytes()), ttl)
+}
+
+/* Find retrieves an image from memory if one is held. */
+func (i *MemoryStreetViewImages) Find(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(Domain.NewImageUuid(latitude, longitude, parameters))
+}
+
+/* FindByPanoId retrieves an image requested by it's panorama id from memory if one is held. */
+func (i *MemoryStreetViewImages) FindByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(Domain.NewPanoImageUuid(panoId, parameters))
+}
+
+/* FindPanorama retrieves a stitched panorama of a location from memory if one is held. */
+func (i *MemoryStreetViewImages) FindPanorama(
+	ctx context.Context, latitude float64, longitude float64, slices int, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(Domain.NewPanoramaImageUuid(latitude, longitude, slices, parameters))
+}
+
+/* FindMany retrieves multiple images from memory, nil for those not held. */
+func (i *MemoryStreetViewImages) FindMany(ctx context.Context, uuids []*Domain.ImageUuid) []Domain.StreetViewImage {
+	images := make([]Domain.StreetViewImage, len(uuids))
+
+	for index, imageUuid := range uuids {
+		images[index] = i.findByUuid(imageUuid)
+	}
+
+	return images
+}
+
+/*
+SaveNoCoverage records that there is no image for the location (or panorama) of the uuid, for the configured time as
+opposed to the time images are held for, as coverage gets added.
+*/
+func (i *MemoryStreetViewImages) SaveNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	key := i.formatNoCoverageKey(imageUuid)
+
+	return i.store(key, nil, 0, i.noCoverageExpiration)
+}
+
+/* HasNoCoverage retrieves whether it is known that there is no image for the location (or panorama) of the uuid. */
+func (i *MemoryStreetViewImages) HasNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.retrieve(i.formatNoCoverageKey(imageUuid)) != nil
+}
+
+/* GetStats retrieves the counters of the images held since the instance started. */
+func (i *MemoryStreetViewImages) GetStats() MemoryCacheStats {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return MemoryCacheStats{
+		Hits:        i.hits,
+		Misses:      i.misses,
+		Evictions:   i.evictions,
+		Expirations: i.expirations,
+		Entries:     i.entries.Len(),
+		Bytes:       i.bytes,
+	}
+}
+
+/* findByUuid retrieves the image held under the given uuid, counting it as a hit or a miss. */
+func (i *MemoryStreetViewImages) findByUuid(imageUuid *Domain.ImageUuid) Domain.StreetViewImage {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	entry := i.retrieve(imageUuid.String())
+
+	if entry == nil || entry.image == nil {
+		i.misses++
+
+		return nil
+	}
+
+	i.hits++
+
+	return entry.image
+}
+
+/*
+store holds the image (or the record of a location without coverage) under the key, replacing any held already, and
+evicts the least recently used entries until the entries fit. The key counts towards the size of every entry.
+*/
+func (i *MemoryStreetViewImages) store(key string, image Domain.StreetViewImage, size int, ttl time.Duration) bool {
+	size += len(key)
+
+	if size > i.maxBytes {
+		i.logger.Debug(fmt.Sprintf("Not holding key: '%s' in memory, byte length: '%d' is over the max", key, size))
+
+		return false
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if element, isHeld := i.elements[key]; isHeld {
+		i.remove(element)
+	}
+
+	i.elements[key] = i.entries.PushFront(&memoryEntry{
+		key:       key,
+		image:     image,
+		size:      size,
+		expiresAt: time.Now().Add(ttl),
+	})
+
+	i.bytes += size
+
+	for i.bytes > i.maxBytes {
+		i.evictions++
+
+		i.remove(i.entries.Back())
+	}
+
+	return true
+}
+
+/*
+retrieve retrieves the entry held under the key, marking it as the most recently used, or nil when none is held. An
+expired entry is removed instead. Requires the lock held.
+*/
+func (i *MemoryStreetViewImages) retrieve(key string) *memoryEntry {
+	element, isHeld := i.elements[key]
+
+	if !isHeld {
+		return nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+
+	if !time.Now().Before(entry.expiresAt) {
+		i.expirations++
+
+		i.remove(element)
+
+		return nil
+	}
+
+	i.entries.MoveToFront(element)
+
+	return entry
+}
+
+/* remove removes the entry of the element from memory. Requires the lock held. */
+func (i *MemoryStreetViewImages) remove(element *list.Element) {
+	entry := i.entries.Remove(element).(*memoryEntry)
+
+	delete(i.elements, entry.key)
+
+	i.bytes -= entry.size
+}
+
+/* formatNoCoverageKey formats the key recording that the location (or panorama) of the uuid has no image. */
+func (i *MemoryStreetViewImages) formatNoCoverageKey(imageUuid *Domain.ImageUuid) string {
+	return fmt.Sprintf("%s:%s", noCoverageKeyPrefix, imageUuid.GetCoverageUuid().String())
+}
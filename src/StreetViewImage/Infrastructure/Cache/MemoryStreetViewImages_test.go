@@ -0,0 +1,196 @@
+package Cache
+
+import (
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+/* newTestMemoryImages returns MemoryStreetViewImages with the variables given set. */
+func newTestMemoryImages(t *testing.T, variables map[string]string) *MemoryStreetViewImages {
+	return NewMemoryStreetViewImages(newTestCacheConfiguration(t, variables), &Logger.LoggingStrategy{})
+}
+
+/* newTestImageUuid returns the uuid of the image of the location, as created by newTestImage. */
+func newTestImageUuid(latitude float64) *Domain.ImageUuid {
+	return Domain.NewImageUuid(latitude, 0, Domain.NewImageParameters(nil, nil, nil, nil, ""))
+}
+
+/* memoryEntrySize returns the size the image is accounted for in memory, it's key included. */
+func memoryEntrySize(image Domain.StreetViewImage) int {
+	return len(image.GetBytes()) + len(image.GetUuid())
+}
+
+/* memoryStep saves the image of the latitude when it has a size in bytes, otherwise finds it. */
+type memoryStep struct {
+	latitude float64
+	size     int
+}
+
+func TestMemoryImagesEvictTheLeastRecentlyUsedBeyondTheMaxBytes(t *testing.T) {
+	size := memoryEntrySize(newTestImage(t, 1, 100, time.Now()))
+
+	tests := []struct {
+		name      string
+		maxBytes  int
+		steps     []memoryStep
+		held      map[float64]bool
+		evictions int
+	}{
+		{
+			name:     "the key counts towards the size",
+			maxBytes: size - 1,
+			steps:    []memoryStep{{1, 100}},
+			held:     map[float64]bool{1: false},
+		},
+		{
+			name:     "an entry as large as the max is held",
+			maxBytes: size,
+			steps:    []memoryStep{{1, 100}},
+			held:     map[float64]bool{1: true},
+		},
+		{
+			name:      "the oldest is evicted",
+			maxBytes:  2 * size,
+			steps:     []memoryStep{{1, 100}, {2, 100}, {3, 100}},
+			held:      map[float64]bool{1: false, 2: true, 3: true},
+			evictions: 1,
+		},
+		{
+			name:      "a found entry is used most recently",
+			maxBytes:  2 * size,
+			steps:     []memoryStep{{1, 100}, {2, 100}, {1, 0}, {3, 100}},
+			held:      map[float64]bool{1: true, 2: false, 3: true},
+			evictions: 1,
+		},
+		{
+			name:      "as many are evicted as it takes",
+			maxBytes:  2 * size,
+			steps:     []memoryStep{{1, 100}, {2, 100}, {3, 150}},
+			held:      map[float64]bool{1: false, 2: false, 3: true},
+			evictions: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			images := newTestMemoryImages(t, map[string]string{"CACHE_MEMORY_MAX_BYTES": strconv.Itoa(test.maxBytes)})
+
+			for _, step := range test.steps {
+				if step.size > 0 {
+					images.Save(context.Background(), newTestImage(t, step.latitude, step.size, time.Now()))
+				} else {
+					findTestImage(images, step.latitude)
+				}
+			}
+
+			stats := images.GetStats()
+
+			if stats.Evictions != test.evictions {
+				t.Errorf("Expected evictions: '%d', got: '%d'", test.evictions, stats.Evictions)
+			}
+
+			if stats.Bytes > test.maxBytes {
+				t.Errorf("Expected the byte length to be within: '%d', got: '%d'", test.maxBytes, stats.Bytes)
+			}
+
+			for latitude, expected := range test.held {
+				if held := findTestImage(images, latitude) != nil; held != expected {
+					t.Errorf("Expected the image: '%v' to be held: '%t', got: '%t'", latitude, expected, held)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryImagesExpireAfterTheirOwnLifetime(t *testing.T) {
+	/* Truncated as the created at of images is, so that when they expire can be compared exactly. */
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name      string
+		save      func(images *MemoryStreetViewImages) bool
+		expiresAt time.Time
+	}{
+		{
+			name: "an image from when it was created",
+			save: func(images *MemoryStreetViewImages) bool {
+				return images.Save(context.Background(), newTestImage(t, 1, 100, now.Add(-30*time.Minute)))
+			},
+			expiresAt: now.Add(30 * time.Minute),
+		},
+		{
+			name: "an image past it's lifetime is not held",
+			save: func(images *MemoryStreetViewImages) bool {
+				return images.Save(context.Background(), newTestImage(t, 1, 100, now.Add(-time.Hour)))
+			},
+		},
+		{
+			name: "a location without coverage for it's own time",
+			save: func(images *MemoryStreetViewImages) bool {
+				return images.SaveNoCoverage(context.Background(), newTestImageUuid(1))
+			},
+			expiresAt: time.Now().Add(24 * time.Hour),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			images := newTestMemoryImages(t, map[string]string{
+				"CACHE_MEMORY_EXPIRATION":             "3600",
+				"CACHE_MEMORY_NO_COVERAGE_EXPIRATION": "86400",
+			})
+
+			if saved := test.save(images); saved != !test.expiresAt.IsZero() {
+				t.Fatalf("Expected to be held: '%t', got: '%t'", !test.expiresAt.IsZero(), saved)
+			}
+
+			if test.expiresAt.IsZero() {
+				return
+			}
+
+			entry := images.entries.Front().Value.(*memoryEntry)
+
+			/* Allowing for the time it took to be saved. */
+			difference := entry.expiresAt.Sub(test.expiresAt)
+
+			if difference < -time.Second || difference > time.Second {
+				t.Errorf("Expected to expire at: '%s', got: '%s'", test.expiresAt, entry.expiresAt)
+			}
+
+			entry.expiresAt = time.Now()
+
+			if images.retrieve(entry.key) != nil {
+				t.Error("Expected the expired entry not to be retrieved")
+			}
+
+			if stats := images.GetStats(); stats.Expirations != 1 || stats.Entries != 0 || stats.Bytes != 0 {
+				t.Errorf("Expected the expired entry to be removed, got: '%+v'", stats)
+			}
+		})
+	}
+}
+
+func TestMemoryImagesCountHitsAndMisses(t *testing.T) {
+	images := newTestMemoryImages(t, map[string]string{})
+
+	images.Save(context.Background(), newTestImage(t, 1, 100, time.Now()))
+	images.SaveNoCoverage(context.Background(), newTestImageUuid(2))
+
+	findTestImage(images, 1)
+	findTestImage(images, 3)
+
+	/* Each of the images counts separately, and a location without coverage is no image to hit. */
+	images.FindMany(context.Background(), []*Domain.ImageUuid{
+		newTestImageUuid(1), newTestImageUuid(2), newTestImageUuid(4),
+	})
+
+	expected := MemoryCacheStats{Hits: 2, Misses: 3, Entries: 2, Bytes: images.bytes}
+
+	if stats := images.GetStats(); stats != expected {
+		t.Errorf("Expected stats: '%+v', got: '%+v'", expected, stats)
+	}
+}
@@ -5,17 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis"
+	"sync"
 	"time"
 )
 
 /* RedisClientFactory exists to delay the initialisation of a Redis client that performs connections in it's init. */
 type RedisClientFactory struct {
 	config *config.RedisConfiguration
+	/* connection is the client connected to by Connect, shared by every copy of the factory. */
+	connection *redisConnection
+}
+
+/*
+redisConnection is the client created by a RedisClientFactory that is re-used until it stops responding, or the error
+creating one when redis could not be connected to.
+*/
+type redisConnection struct {
+	mutex  sync.Mutex
+	client *redis.Client
+	/* err is the error the last attempt to connect failed with, at failedAt, nil once connected. */
+	err      error
+	failedAt time.Time
 }
 
 /* NewRedisClientFactory returns a newly initialised RedisClientFactory ready to initialise a client at runtime. */
 func NewRedisClientFactory(config config.RedisConfiguration) *RedisClientFactory {
-	return &RedisClientFactory{config: &config}
+	return &RedisClientFactory{config: &config, connection: &redisConnection{}}
 }
 
 /* Create returns an initialised redis.Client if the ping executes successfully, otherwise errors. */
@@ -23,12 +38,18 @@ func (f *RedisClientFactory) Create() (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:       f.formatAddress(f.config.GetHostname(), f.config.GetPort()),
 		MaxRetries: f.config.GetMaxRetries(),
+		/* Bounds every attempt, as a call to an unreachable redis otherwise waits on the dial and read for long. */
+		DialTimeout:  time.Duration(f.config.GetTimeout()) * time.Second,
+		ReadTimeout:  time.Duration(f.config.GetTimeout()) * time.Second,
+		WriteTimeout: time.Duration(f.config.GetTimeout()) * time.Second,
 		/* Looks like jitter on the backoff could be client-specified unfortunately. Oh well. */
 		MinRetryBackoff: time.Duration(float64(f.config.GetRetryDelay()) * time.Second.Seconds()),
 		MaxRetryBackoff: time.Duration(float64(f.config.GetRetryDelay()) * time.Second.Seconds()),
 	})
 
 	if result := client.Ping(); result.Err() != nil {
+		_ = client.Close()
+
 		return nil, errors.New(
 			fmt.Sprintf(
 				"unable to connect to redis on host: %s, port :%d, error: %s",
@@ -40,6 +61,58 @@ func (f *RedisClientFactory) Create() (*redis.Client, error) {
 	return client, nil
 }
 
+/*
+Connect returns the client connected to last as long as it still responds to a ping, otherwise creates a new one. The
+client is shared by every copy of the factory, and when it stops responding only one caller at a time creates a new
+one, those waiting on it re-using it rather than each creating (and leaking) their own.
+
+Once connecting fails, the error is returned without trying again until the retry delay has passed, so that every call
+made whilst redis is down does not wait on connecting (and retrying) in turn.
+*/
+func (f *RedisClientFactory) Connect() (*redis.Client, error) {
+	f.connection.mutex.Lock()
+	client := f.connection.client
+	f.connection.mutex.Unlock()
+
+	if client != nil && client.Ping().Err() == nil {
+		return client, nil
+	}
+
+	f.connection.mutex.Lock()
+	defer f.connection.mutex.Unlock()
+
+	/* Another caller has connected whilst this one was pinging or waiting for the lock. */
+	if f.connection.client != client {
+		return f.connection.client, nil
+	}
+
+	retryDelay := time.Duration(f.config.GetRetryDelay()) * time.Second
+
+	if f.connection.err != nil && time.Since(f.connection.failedAt) < retryDelay {
+		return nil, f.connection.err
+	}
+
+	if client != nil {
+		_ = client.Close()
+
+		f.connection.client = nil
+	}
+
+	newClient, err := f.Create()
+
+	if err != nil {
+		f.connection.err = err
+		f.connection.failedAt = time.Now()
+
+		return nil, err
+	}
+
+	f.connection.client = newClient
+	f.connection.err = nil
+
+	return newClient, nil
+}
+
 /* formatAddress formats the host and port into an address for the Addr field of redis.Options. */
 func (*RedisClientFactory) formatAddress(hostname string, port int) string {
 	return fmt.Sprintf("%s:%d", hostname, port)
@@ -0,0 +1,26 @@
+package Cache
+
+import "context"
+
+/*
+RunWithContext runs a call to redis, returning the context's error instead as soon as the context is done. The redis
+client does not abort calls by context itself, so an abandoned call still completes in the background, bounded by the
+client's timeouts and retries (see: RedisClientFactory), and the caller must not use anything the call sets after it is
+abandoned.
+*/
+func RunWithContext(ctx context.Context, call func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+
+	go func() { done <- call() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
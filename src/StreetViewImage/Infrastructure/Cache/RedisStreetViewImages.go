@@ -1,39 +1,64 @@
 package Cache
 
 import (
+	"app/config"
 	"app/src/StreetViewImage/Domain"
 	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
 	"fmt"
 	"github.com/go-redis/redis"
+	"strings"
 	"time"
 )
 
 /* redisKeyExpiration is the amount of time an image is stored in redis for. */
 const redisKeyExpiration = time.Duration(1337 * time.Hour)
 
-/* RedisStreetViewImages is a Repository responsible for persisting to Redis. */
+/* noCoverageKeyPrefix prefixes the coverage uuid of a location (or panorama) that is known to have no image. */
+const noCoverageKeyPrefix = "no_coverage"
+
+/* providerSeparator separates the imagery provider of an image from it's bytes in a stored value. */
+const providerSeparator = "\n"
+
+/* jpegMagicBytes start every jpeg, so a stored value starting with them is image bytes stored without a provider. */
+const jpegMagicBytes = "\xff\xd8"
+
+/*
+RedisStreetViewImages is a Repository responsible for persisting to Redis. Every call gives up once the context of the
+request is done, the image being treated as not found (or not stored) just like when redis is unavailable.
+*/
 type RedisStreetViewImages struct {
+	Config             *config.RedisConfiguration
 	RedisClientFactory RedisClientFactory
-	/* redisClient is the factory's created client that is re-used until the connection fails. */
-	redisClient *redis.Client
-	Logger      Logger.LoggingStrategy
+	Logger             Logger.LoggingStrategy
 }
 
 /* Save stores the image in Redis and returns whether or not this storing was successful. */
-func (i *RedisStreetViewImages) Save(image Domain.StreetViewImage) bool {
-	client := i.retrieveConnectedRedisClient()
+func (i *RedisStreetViewImages) Save(ctx context.Context, image Domain.StreetViewImage) bool {
+	client := i.retrieveConnectedRedisClient(ctx)
 
 	if client == nil {
 		return false
 	}
 
 	redisKey := image.GetUuid()
-	redisVal := i.marshalBytesForStorage(image.GetBytes())
+	redisVal := i.marshalImageForStorage(image)
+
+	/* An image keeps the lifetime it was retrieved with, so one promoted from another tier is not stored for longer. */
+	expiration := (redisKeyExpiration - time.Since(image.GetCreatedAt())).Truncate(time.Second)
+
+	if expiration <= 0 {
+		i.Logger.Debug(fmt.Sprintf("Not storing key: '%s' in redis, it's lifetime has passed", redisKey))
+
+		return false
+	}
 
 	/* Pretty sure at this point that this won't fail, but you never know... */
-	status := client.Set(redisKey, redisVal, redisKeyExpiration)
+	err := RunWithContext(ctx, func() error {
+		return client.Set(redisKey, redisVal, expiration).Err()
+	})
 
-	if _, err := status.Result(); err != nil {
+	if err != nil {
 		i.Logger.Warning(fmt.Sprintf("Could not store value redis, reason: '%s'", err.Error()))
 
 		return false
@@ -45,22 +70,159 @@ func (i *RedisStreetViewImages) Save(image Domain.StreetViewImage) bool {
 }
 
 /* Find retrieves an image from persistence if one exists. */
-func (i *RedisStreetViewImages) Find(latitude float64, longitude float64) Domain.StreetViewImage {
-	client := i.retrieveConnectedRedisClient()
+func (i *RedisStreetViewImages) Find(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(ctx, Domain.NewImageUuid(latitude, longitude, parameters))
+}
+
+/* FindByPanoId retrieves an image requested by it's panorama id from persistence if one exists. */
+func (i *RedisStreetViewImages) FindByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(ctx, Domain.NewPanoImageUuid(panoId, parameters))
+}
+
+/* FindPanorama retrieves a stitched panorama of a location from persistence if one exists. */
+func (i *RedisStreetViewImages) FindPanorama(
+	ctx context.Context, latitude float64, longitude float64, slices int, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.findByUuid(ctx, Domain.NewPanoramaImageUuid(latitude, longitude, slices, parameters))
+}
+
+/* FindMany retrieves multiple images from persistence in a single round trip (MGET), nil for those not found. */
+func (i *RedisStreetViewImages) FindMany(ctx context.Context, uuids []*Domain.ImageUuid) []Domain.StreetViewImage {
+	images := make([]Domain.StreetViewImage, len(uuids))
+
+	if len(uuids) == 0 {
+		return images
+	}
+
+	client := i.retrieveConnectedRedisClient(ctx)
+
+	if client == nil {
+		return images
+	}
+
+	keys := make([]string, len(uuids))
+
+	for index, imageUuid := range uuids {
+		keys[index] = imageUuid.String()
+	}
+
+	var values []interface{}
+
+	err := RunWithContext(ctx, func() (err error) {
+		values, err = client.MGet(keys...).Result()
+
+		return err
+	})
+
+	if err != nil {
+		i.Logger.Warning(fmt.Sprintf("Could not retrieve values from redis, reason: '%s'", err.Error()))
+
+		return images
+	}
+
+	for index, value := range values {
+		/* Keys that do not exist are returned as nil by MGET. */
+		storedValue, isString := value.(string)
+
+		if !isString {
+			continue
+		}
+
+		imageBytes, provider := i.unmarshalStoredValue(storedValue, uuids[index])
+
+		image, err := Domain.NewStreetViewImageFromUuid(uuids[index], imageBytes, provider)
+
+		if err != nil {
+			i.Logger.Warning(fmt.Sprintf("Image bytes retrieved from redis invalid, reason: '%s'", err.Error()))
+
+			continue
+		}
+
+		images[index] = image
+	}
+
+	return images
+}
+
+/*
+SaveNoCoverage records that there is no image for the location (or panorama) of the uuid, for the configured time as
+opposed to the (much longer) time images are stored for, as coverage gets added.
+*/
+func (i *RedisStreetViewImages) SaveNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	client := i.retrieveConnectedRedisClient(ctx)
+
+	if client == nil {
+		return false
+	}
+
+	redisKey := i.formatNoCoverageKey(imageUuid)
+	expiration := time.Duration(i.Config.GetNoCoverageExpiration()) * time.Second
+
+	if err := RunWithContext(ctx, func() error { return client.Set(redisKey, 1, expiration).Err() }); err != nil {
+		i.Logger.Warning(fmt.Sprintf("Could not store no coverage in redis, reason: '%s'", err.Error()))
+
+		return false
+	}
+
+	i.Logger.Debug(fmt.Sprintf("Stored key: '%s' in redis for: '%s'", redisKey, expiration))
+
+	return true
+}
+
+/* HasNoCoverage retrieves whether it is known that there is no image for the location (or panorama) of the uuid. */
+func (i *RedisStreetViewImages) HasNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	client := i.retrieveConnectedRedisClient(ctx)
+
+	if client == nil {
+		return false
+	}
+
+	var exists int64
+
+	err := RunWithContext(ctx, func() (err error) {
+		exists, err = client.Exists(i.formatNoCoverageKey(imageUuid)).Result()
+
+		return err
+	})
+
+	return err == nil && exists > 0
+}
+
+/* formatNoCoverageKey formats the redis key recording that the location (or panorama) of the uuid has no image. */
+func (i *RedisStreetViewImages) formatNoCoverageKey(imageUuid *Domain.ImageUuid) string {
+	return fmt.Sprintf("%s:%s", noCoverageKeyPrefix, imageUuid.GetCoverageUuid().String())
+}
+
+/* findByUuid retrieves the image stored under the given uuid from persistence if one exists. */
+func (i *RedisStreetViewImages) findByUuid(ctx context.Context, imageUuid *Domain.ImageUuid) Domain.StreetViewImage {
+	client := i.retrieveConnectedRedisClient(ctx)
 
 	if client == nil {
 		return nil
 	}
 
-	imageUuid := Domain.NewImageUuid(latitude, longitude)
+	pipeline := client.Pipeline()
+
+	get := pipeline.Get(imageUuid.String())
+	ttl := pipeline.TTL(imageUuid.String())
 
-	imageBytes, err := client.Get(imageUuid.String()).Result()
+	err := RunWithContext(ctx, func() error {
+		_, err := pipeline.Exec()
+
+		return err
+	})
 
 	if err != nil {
 		return nil
 	}
 
-	image, err := Domain.NewStreetViewImage(latitude, longitude, i.unmarshalStoredBytes(imageBytes))
+	imageBytes, provider := i.unmarshalStoredValue(get.Val(), imageUuid)
+
+	image, err := Domain.NewStoredStreetViewImage(imageUuid, imageBytes, i.calculateCreatedAt(ttl.Val()), provider)
 
 	if err != nil {
 		i.Logger.Warning(fmt.Sprintf("Image bytes retrieved from redis invalid, reason: '%s'", err.Error()))
@@ -71,33 +233,68 @@ func (i *RedisStreetViewImages) Find(latitude float64, longitude float64) Domain
 	return image
 }
 
-/* retrieveConnectedRedisClient attempts to use the stored redis client to connect, otherwise builds a new one. */
-func (i *RedisStreetViewImages) retrieveConnectedRedisClient() *redis.Client {
-	if i.redisClient != nil {
-		if _, err := i.redisClient.Ping().Result(); err == nil {
-			return i.redisClient
-		}
-	}
+/*
+retrieveConnectedRedisClient retrieves the client connected to by the factory, nil when redis can not be connected to
+or the context is done before connecting, as connecting (and retrying) may take a while.
+*/
+func (i *RedisStreetViewImages) retrieveConnectedRedisClient(ctx context.Context) *redis.Client {
+	var client *redis.Client
+
+	err := RunWithContext(ctx, func() (err error) {
+		client, err = i.RedisClientFactory.Connect()
 
-	client, err := i.RedisClientFactory.Create()
+		return err
+	})
 
 	if err != nil {
-		i.Logger.Warning(err.Error())
+		if ctx.Err() == nil {
+			i.Logger.Warning(err.Error())
+		}
 
 		return nil
 	}
 
-	i.redisClient = client
+	return client
+}
 
-	return i.redisClient
+/*
+calculateCreatedAt calculates when an image was created from the remaining time to live of it's key, as every key is
+stored to expire the same time after the image was created. Keys without an expiration are considered to have just
+been stored.
+*/
+func (i *RedisStreetViewImages) calculateCreatedAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Now()
+	}
+
+	return time.Now().Add(ttl - redisKeyExpiration)
 }
 
-/* marshalBytesForStorage converts a StreetViewImage's bytes into a format for storage as a redis value. */
-func (i *RedisStreetViewImages) marshalBytesForStorage(bytes []byte) string {
-	return string(bytes)
+/*
+marshalImageForStorage converts a StreetViewImage into a format for storage as a redis value: the imagery provider it
+was retrieved from, then it's bytes.
+*/
+func (i *RedisStreetViewImages) marshalImageForStorage(image Domain.StreetViewImage) string {
+	return image.GetProvider() + providerSeparator + string(image.GetBytes())
 }
 
-/* unmarshalStoredBytes converts a StreetViewImage's bytes, stored as a string value in redis, back to bytes. */
-func (i *RedisStreetViewImages) unmarshalStoredBytes(str string) []byte {
-	return []byte(str)
+/*
+unmarshalStoredValue converts a stored redis value back to a StreetViewImage's bytes and the imagery provider it was
+retrieved from. Values stored before providers were recorded are just the bytes, so the provider is taken from the uuid
+as there was only ever a single provider then.
+*/
+func (i *RedisStreetViewImages) unmarshalStoredValue(str string, imageUuid *Domain.ImageUuid) ([]byte, string) {
+	separatorIndex := strings.Index(str, providerSeparator)
+
+	if strings.HasPrefix(str, jpegMagicBytes) || separatorIndex < 0 {
+		provider := imageUuid.GetParameters().GetProvider()
+
+		if provider == "" {
+			provider = Domain.ProviderDefault
+		}
+
+		return []byte(str), provider
+	}
+
+	return []byte(str[separatorIndex+len(providerSeparator):]), str[:separatorIndex]
 }
@@ -0,0 +1,381 @@
+package Cache
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+/* tierSeparator separates the configured tiers. */
+const tierSeparator = ","
+
+/* TierStats are the counters of a single tier of a TieredStreetViewImages since the instance started. */
+type TierStats struct {
+	Name string
+	/* Hits and Misses count the images found and not found in the tier. */
+	Hits   int64
+	Misses int64
+	/*
+		NoCoverageHits and NoCoverageMisses count the locations the tier does and does not know to be without coverage,
+		apart from the images as the coverage is checked for every image missed.
+	*/
+	NoCoverageHits   int64
+	NoCoverageMisses int64
+	/* Promotions counts the images (and locations without coverage) found below the tier and then saved to it. */
+	Promotions int64
+}
+
+/*
+TieredStreetViewImages is a Repository composed of the configured stores in tiers, for example memory in front of redis
+in front of disk. Reads check each tier in turn, an image found in a tier being promoted to every tier above it so that
+the next read for it is answered sooner, whereas writes go through to the configured tiers only.
+
+It's counters are logged at the configured interval.
+*/
+type TieredStreetViewImages struct {
+	logger *Logger.LoggingStrategy
+
+	tiers         []*storeTier
+	statsInterval time.Duration
+}
+
+/* storeTier is a single tier of a TieredStreetViewImages, and it's counters. */
+type storeTier struct {
+	name   string
+	images Domain.StreetViewImages
+	/* isWritten is whether images are saved to the tier, as opposed to only being promoted to it. */
+	isWritten bool
+
+	hits             int64
+	misses           int64
+	noCoverageHits   int64
+	noCoverageMisses int64
+	promotions       int64
+}
+
+/*
+NewTieredStreetViewImages returns a new TieredStreetViewImages of the configured tiers, in order, creating the store of
+each one by it's name (see: StoreRedis, StoreMemory, StoreDisk). Every tier is written to unless the tiers written to
+are configured. An error is returned when the tiers are invalid, or when a store cannot be created.
+*/
+func NewTieredStreetViewImages(
+	config config.CacheConfiguration,
+	createStore func(name string) (Domain.StreetViewImages, error),
+	logger *Logger.LoggingStrategy,
+) (*TieredStreetViewImages, error) {
+	names, err := parseTiers(config.GetTiers())
+
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := parseWriteTiers(config.GetWriteTiers(), names)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tiered := &TieredStreetViewImages{
+		logger:        logger,
+		statsInterval: time.Duration(config.GetStatsInterval()) * time.Second,
+	}
+
+	for _, name := range names {
+		images, err := createStore(name)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to create cache tier: '%s', error: '%s'", name, err.Error()))
+		}
+
+		tiered.tiers = append(tiered.tiers, &storeTier{name: name, images: images, isWritten: written[name]})
+	}
+
+	return tiered, nil
+}
+
+/*
+StartLoggingStats logs the counters of every tier at the configured interval in the background, along with the size of
+the tiers that keep count of it, for the sizes of the tiers to be tuned.
+*/
+func (i *TieredStreetViewImages) StartLoggingStats() {
+	if i.statsInterval <= 0 {
+		return
+	}
+
+	go func() {
+		for range time.Tick(i.statsInterval) {
+			i.logStats()
+		}
+	}()
+}
+
+/* GetStats retrieves the counters of every tier, in order. */
+func (i *TieredStreetViewImages) GetStats() []TierStats {
+	stats := make([]TierStats, len(i.tiers))
+
+	for index, tier := range i.tiers {
+		stats[index] = TierStats{
+			Name:             tier.name,
+			Hits:             atomic.LoadInt64(&tier.hits),
+			Misses:           atomic.LoadInt64(&tier.misses),
+			NoCoverageHits:   atomic.LoadInt64(&tier.noCoverageHits),
+			NoCoverageMisses: atomic.LoadInt64(&tier.noCoverageMisses),
+			Promotions:       atomic.LoadInt64(&tier.promotions),
+		}
+	}
+
+	return stats
+}
+
+/* Save stores the image in every tier written to, and returns whether or not it was stored in any of them. */
+func (i *TieredStreetViewImages) Save(ctx context.Context, image Domain.StreetViewImage) bool {
+	isSaved := false
+
+	for _, tier := range i.tiers {
+		if tier.isWritten && tier.images.Save(ctx, image) {
+			isSaved = true
+		}
+	}
+
+	return isSaved
+}
+
+/* Find retrieves an image from the first tier it is found in, promoting it to the tiers above. */
+func (i *TieredStreetViewImages) Find(
+	ctx context.Context, latitude float64, longitude float64, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.find(ctx, func(images Domain.StreetViewImages) Domain.StreetViewImage {
+		return images.Find(ctx, latitude, longitude, parameters)
+	})
+}
+
+/* FindByPanoId retrieves an image requested by it's panorama id from the first tier it is found in, promoting it. */
+func (i *TieredStreetViewImages) FindByPanoId(
+	ctx context.Context, panoId string, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.find(ctx, func(images Domain.StreetViewImages) Domain.StreetViewImage {
+		return images.FindByPanoId(ctx, panoId, parameters)
+	})
+}
+
+/* FindPanorama retrieves a stitched panorama of a location from the first tier it is found in, promoting it. */
+func (i *TieredStreetViewImages) FindPanorama(
+	ctx context.Context, latitude float64, longitude float64, slices int, parameters *Domain.ImageParameters,
+) Domain.StreetViewImage {
+	return i.find(ctx, func(images Domain.StreetViewImages) Domain.StreetViewImage {
+		return images.FindPanorama(ctx, latitude, longitude, slices, parameters)
+	})
+}
+
+/*
+FindMany retrieves multiple images, nil for those not found, from each tier in turn: only the images not found in the
+tiers above are retrieved from a tier, those found in it being promoted to the tiers above.
+*/
+func (i *TieredStreetViewImages) FindMany(ctx context.Context, uuids []*Domain.ImageUuid) []Domain.StreetViewImage {
+	images := make([]Domain.StreetViewImage, len(uuids))
+
+	/* missing are the indexes of the uuids not found in the tiers so far. */
+	missing := make([]int, len(uuids))
+
+	for index := range uuids {
+		missing[index] = index
+	}
+
+	for tierIndex, tier := range i.tiers {
+		if len(missing) == 0 {
+			break
+		}
+
+		missingUuids := make([]*Domain.ImageUuid, len(missing))
+
+		for index, uuidIndex := range missing {
+			missingUuids[index] = uuids[uuidIndex]
+		}
+
+		var stillMissing []int
+
+		for index, image := range tier.images.FindMany(ctx, missingUuids) {
+			if image == nil {
+				stillMissing = append(stillMissing, missing[index])
+
+				continue
+			}
+
+			images[missing[index]] = image
+
+			i.promote(ctx, tierIndex, func(images Domain.StreetViewImages) bool { return images.Save(ctx, image) })
+		}
+
+		atomic.AddInt64(&tier.hits, int64(len(missing)-len(stillMissing)))
+		atomic.AddInt64(&tier.misses, int64(len(stillMissing)))
+
+		missing = stillMissing
+	}
+
+	return images
+}
+
+/* SaveNoCoverage records that there is no image for the location (or panorama) of the uuid in every tier written to. */
+func (i *TieredStreetViewImages) SaveNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	isSaved := false
+
+	for _, tier := range i.tiers {
+		if tier.isWritten && tier.images.SaveNoCoverage(ctx, imageUuid) {
+			isSaved = true
+		}
+	}
+
+	return isSaved
+}
+
+/*
+HasNoCoverage retrieves whether any tier knows that there is no image for the location (or panorama) of the uuid,
+promoting this to the tiers above.
+*/
+func (i *TieredStreetViewImages) HasNoCoverage(ctx context.Context, imageUuid *Domain.ImageUuid) bool {
+	for tierIndex, tier := range i.tiers {
+		if !tier.images.HasNoCoverage(ctx, imageUuid) {
+			atomic.AddInt64(&tier.noCoverageMisses, 1)
+
+			continue
+		}
+
+		atomic.AddInt64(&tier.noCoverageHits, 1)
+
+		i.promote(ctx, tierIndex, func(images Domain.StreetViewImages) bool {
+			return images.SaveNoCoverage(ctx, imageUuid)
+		})
+
+		return true
+	}
+
+	return false
+}
+
+/* find retrieves an image from the first tier it is found in, promoting it to the tiers above. */
+func (i *TieredStreetViewImages) find(
+	ctx context.Context, findIn func(images Domain.StreetViewImages) Domain.StreetViewImage,
+) Domain.StreetViewImage {
+	for tierIndex, tier := range i.tiers {
+		image := findIn(tier.images)
+
+		if image == nil {
+			atomic.AddInt64(&tier.misses, 1)
+
+			continue
+		}
+
+		atomic.AddInt64(&tier.hits, 1)
+
+		i.promote(ctx, tierIndex, func(images Domain.StreetViewImages) bool { return images.Save(ctx, image) })
+
+		return image
+	}
+
+	return nil
+}
+
+/*
+promote saves what was found in the tier of the index to every tier above it, written to or not. An image keeps when it
+was created, each tier storing it for what is left of it's lifetime there rather than afresh.
+*/
+func (i *TieredStreetViewImages) promote(
+	ctx context.Context, tierIndex int, saveTo func(images Domain.StreetViewImages) bool,
+) {
+	for _, tier := range i.tiers[:tierIndex] {
+		if saveTo(tier.images) {
+			atomic.AddInt64(&tier.promotions, 1)
+		}
+	}
+}
+
+/* logStats logs the counters of every tier, along with the counters a MemoryStreetViewImages keeps of it's size. */
+func (i *TieredStreetViewImages) logStats() {
+	for index, stats := range i.GetStats() {
+		message := fmt.Sprintf(
+			"Cache tier: '%s' hits: '%d' misses: '%d' no coverage hits: '%d' no coverage misses: '%d' promotions: '%d'",
+			stats.Name, stats.Hits, stats.Misses, stats.NoCoverageHits, stats.NoCoverageMisses, stats.Promotions,
+		)
+
+		if memoryImages, isMemory := i.tiers[index].images.(*MemoryStreetViewImages); isMemory {
+			memoryStats := memoryImages.GetStats()
+
+			message += fmt.Sprintf(
+				" evictions: '%d' expirations: '%d' entries: '%d' byte length: '%d'",
+				memoryStats.Evictions, memoryStats.Expirations, memoryStats.Entries, memoryStats.Bytes,
+			)
+		}
+
+		i.logger.Info(message)
+	}
+}
+
+/* parseTiers parses the comma separated tiers, returning an error if there are none or when one is repeated. */
+func parseTiers(tiers string) ([]string, error) {
+	var names []string
+
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(tiers, tierSeparator) {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "" {
+			continue
+		}
+
+		if seen[name] {
+			return nil, errors.New(fmt.Sprintf("cache tier: '%s' is configured more than once", name))
+		}
+
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, errors.New("no cache tiers are configured")
+	}
+
+	return names, nil
+}
+
+/*
+parseWriteTiers parses the comma separated tiers written to, every one of the tiers when none are configured, returning
+an error when one is not one of the tiers.
+*/
+func parseWriteTiers(writeTiers string, names []string) (map[string]bool, error) {
+	written := make(map[string]bool)
+
+	if strings.TrimSpace(writeTiers) == "" {
+		for _, name := range names {
+			written[name] = true
+		}
+
+		return written, nil
+	}
+
+	writtenNames, err := parseTiers(writeTiers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		written[name] = false
+	}
+
+	for _, writtenName := range writtenNames {
+		if _, isTier := written[writtenName]; !isTier {
+			return nil, errors.New(fmt.Sprintf("cache tier: '%s' is written to but is not one of the tiers", writtenName))
+		}
+
+		written[writtenName] = true
+	}
+
+	return written, nil
+}
@@ -0,0 +1,228 @@
+package Cache
+
+import (
+	"app/src/StreetViewImage/Domain"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"context"
+	"testing"
+	"time"
+)
+
+/*
+newTestTieredImages returns TieredStreetViewImages of the tiers with the variables given set, along with the store of
+each tier by it's name. The disk tier is stored in a temporary directory, and every other tier is held in memory.
+*/
+func newTestTieredImages(
+	t *testing.T, tiers string, variables map[string]string,
+) (*TieredStreetViewImages, map[string]Domain.StreetViewImages) {
+	variables["CACHE_TIERS"] = tiers
+	variables["CACHE_DISK_DIRECTORY"] = t.TempDir()
+
+	cacheConfig := newTestCacheConfiguration(t, variables)
+	logger := &Logger.LoggingStrategy{}
+	stores := make(map[string]Domain.StreetViewImages)
+
+	tiered, err := NewTieredStreetViewImages(cacheConfig, func(name string) (Domain.StreetViewImages, error) {
+		if name == StoreDisk {
+			diskImages := NewFilesystemStreetViewImages(cacheConfig, logger)
+			stores[name] = diskImages
+
+			return diskImages, diskImages.Open()
+		}
+
+		stores[name] = NewMemoryStreetViewImages(cacheConfig, logger)
+
+		return stores[name], nil
+	}, logger)
+
+	if err != nil {
+		t.Fatalf("Unable to create the tiers, error: '%s'", err.Error())
+	}
+
+	return tiered, stores
+}
+
+/* assertTierStats fails the test unless the counters of every tier are as expected, in order. */
+func assertTierStats(t *testing.T, images *TieredStreetViewImages, expected []TierStats) {
+	t.Helper()
+
+	stats := images.GetStats()
+
+	for index := range expected {
+		if stats[index] != expected[index] {
+			t.Errorf("Expected the stats of tier: '%d' to be: '%+v', got: '%+v'", index, expected[index], stats[index])
+		}
+	}
+}
+
+func TestTieredImagesArePromotedToEveryTierAbove(t *testing.T) {
+	images, stores := newTestTieredImages(t, "first,second,third", map[string]string{"CACHE_WRITE_TIERS": "third"})
+
+	images.Save(context.Background(), newTestImage(t, 1, 100, time.Now()))
+
+	if findTestImage(images, 1) == nil {
+		t.Fatal("Expected the image to be found in the last tier")
+	}
+
+	assertTierStats(t, images, []TierStats{
+		{Name: "first", Misses: 1, Promotions: 1},
+		{Name: "second", Misses: 1, Promotions: 1},
+		{Name: "third", Hits: 1},
+	})
+
+	/* Once promoted the image is found in the first tier, without reading the tiers below. */
+	findTestImage(images, 1)
+
+	assertTierStats(t, images, []TierStats{
+		{Name: "first", Hits: 1, Misses: 1, Promotions: 1},
+		{Name: "second", Misses: 1, Promotions: 1},
+		{Name: "third", Hits: 1},
+	})
+
+	for _, name := range []string{"first", "second"} {
+		if findTestImage(stores[name], 1) == nil {
+			t.Errorf("Expected the image to be promoted to tier: '%s'", name)
+		}
+	}
+}
+
+func TestTieredImagesFindManyPromotesOnlyWhatIsMissingAbove(t *testing.T) {
+	images, _ := newTestTieredImages(t, "first,second", map[string]string{"CACHE_WRITE_TIERS": "second"})
+
+	images.Save(context.Background(), newTestImage(t, 1, 100, time.Now()))
+	images.Save(context.Background(), newTestImage(t, 2, 100, time.Now()))
+
+	findTestImage(images, 1)
+
+	found := images.FindMany(context.Background(), []*Domain.ImageUuid{
+		newTestImageUuid(1), newTestImageUuid(2), newTestImageUuid(3),
+	})
+
+	if found[0] == nil || found[1] == nil || found[2] != nil {
+		t.Errorf("Expected the first two images to be found, got: '%v'", found)
+	}
+
+	/* The first image is already in the first tier, so only the second is retrieved from (and promoted from) below. */
+	assertTierStats(t, images, []TierStats{
+		{Name: "first", Hits: 1, Misses: 3, Promotions: 2},
+		{Name: "second", Hits: 2, Misses: 1},
+	})
+}
+
+func TestTieredImagesAreWrittenToTheWriteTiersOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		writeTiers string
+		written    map[string]bool
+	}{
+		{name: "every tier by default", writeTiers: "", written: map[string]bool{"first": true, "second": true}},
+		{name: "the write tiers", writeTiers: "second", written: map[string]bool{"first": false, "second": true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			images, stores := newTestTieredImages(t, "first,second", map[string]string{
+				"CACHE_WRITE_TIERS": test.writeTiers,
+			})
+
+			if !images.Save(context.Background(), newTestImage(t, 1, 100, time.Now())) {
+				t.Error("Expected the image to be saved")
+			}
+
+			if !images.SaveNoCoverage(context.Background(), newTestImageUuid(2)) {
+				t.Error("Expected the location without coverage to be saved")
+			}
+
+			for name, expected := range test.written {
+				if isSaved := findTestImage(stores[name], 1) != nil; isSaved != expected {
+					t.Errorf("Expected the image to be saved to tier: '%s': '%t', got: '%t'", name, expected, isSaved)
+				}
+
+				isSaved := stores[name].HasNoCoverage(context.Background(), newTestImageUuid(2))
+
+				if isSaved != expected {
+					t.Errorf("Expected no coverage to be saved to tier: '%s': '%t', got: '%t'", name, expected, isSaved)
+				}
+			}
+		})
+	}
+}
+
+func TestTieredImagesKeepTheirLifetimeWhenPromoted(t *testing.T) {
+	/* Truncated as the created at of images is, so that when they expire can be compared exactly. */
+	createdAt := time.Now().Truncate(time.Second).Add(-50 * time.Minute)
+
+	tests := []struct {
+		name             string
+		memoryExpiration string
+		/* expiresAt is when the image expires in memory, zero when it is not promoted to memory. */
+		expiresAt time.Time
+	}{
+		{name: "what is left of it's lifetime", memoryExpiration: "3600", expiresAt: createdAt.Add(time.Hour)},
+		{name: "not once it's lifetime has passed", memoryExpiration: "600"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			images, stores := newTestTieredImages(t, "memory,disk", map[string]string{
+				"CACHE_WRITE_TIERS":       "disk",
+				"CACHE_MEMORY_EXPIRATION": test.memoryExpiration,
+			})
+
+			images.Save(context.Background(), newTestImage(t, 1, 100, createdAt))
+
+			image := findTestImage(images, 1)
+
+			/* It is still served from the tier it was found in, however long it could be held in memory. */
+			if image == nil || !image.GetCreatedAt().Equal(createdAt) {
+				t.Fatalf("Expected the image created at: '%s', got: '%v'", createdAt, image)
+			}
+
+			memoryImages := stores[StoreMemory].(*MemoryStreetViewImages)
+
+			if test.expiresAt.IsZero() {
+				if memoryImages.entries.Len() != 0 {
+					t.Errorf("Expected the image not to be promoted, got: '%d' entries", memoryImages.entries.Len())
+				}
+
+				return
+			}
+
+			if memoryImages.entries.Len() != 1 {
+				t.Fatalf("Expected the image to be promoted, got: '%d' entries", memoryImages.entries.Len())
+			}
+
+			entry := memoryImages.entries.Front().Value.(*memoryEntry)
+
+			/* Allowing for the time it took to be promoted. */
+			difference := entry.expiresAt.Sub(test.expiresAt)
+
+			if difference < -time.Second || difference > time.Second {
+				t.Errorf("Expected to expire at: '%s', got: '%s'", test.expiresAt, entry.expiresAt)
+			}
+		})
+	}
+}
+
+func TestTieredImagesCountNoCoverageApartFromImages(t *testing.T) {
+	images, stores := newTestTieredImages(t, "first,second", map[string]string{"CACHE_WRITE_TIERS": "second"})
+
+	images.SaveNoCoverage(context.Background(), newTestImageUuid(1))
+
+	if !images.HasNoCoverage(context.Background(), newTestImageUuid(1)) {
+		t.Error("Expected the location to be known to have no coverage")
+	}
+
+	if images.HasNoCoverage(context.Background(), newTestImageUuid(2)) {
+		t.Error("Expected the location not to be known to have no coverage")
+	}
+
+	assertTierStats(t, images, []TierStats{
+		{Name: "first", NoCoverageMisses: 2, Promotions: 1},
+		{Name: "second", NoCoverageHits: 1, NoCoverageMisses: 1},
+	})
+
+	if !stores["first"].HasNoCoverage(context.Background(), newTestImageUuid(1)) {
+		t.Error("Expected the location without coverage to be promoted")
+	}
+}
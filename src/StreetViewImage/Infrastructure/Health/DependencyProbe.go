@@ -0,0 +1,104 @@
+package Health
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Cache"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/olivere/elastic"
+	"net/url"
+	"time"
+)
+
+const (
+	/* probeTimeout is the maximum amount of time a single probe over the network may take. */
+	probeTimeout = time.Duration(2 * time.Second)
+
+	/* Currently http-only, like the ElasticSearchLogger. */
+	elasticUrlFormat = "http://%s:%d"
+
+	/*
+		failedCallTtl is how long a failed call to the StreetView API marks it as unhealthy for. Without this, no further
+		calls may be routed to us to prove that it has recovered.
+	*/
+	failedCallTtl = time.Duration(1 * time.Minute)
+)
+
+/* dependencyProbe checks whether a single dependency of the application is reachable. */
+type dependencyProbe interface {
+	/* Probe returns an error describing why the dependency is unhealthy, or nil when it is healthy. */
+	Probe() error
+}
+
+/* redisProbe checks that redis is reachable by connecting to it. */
+type redisProbe struct {
+	clientFactory *Cache.RedisClientFactory
+}
+
+/* Probe returns an error describing why redis is unreachable, or nil when it is reachable. */
+func (p *redisProbe) Probe() error {
+	client, err := p.clientFactory.Create()
+
+	if err != nil {
+		return err
+	}
+
+	return client.Close()
+}
+
+/* elasticSearchProbe checks that elasticsearch is reachable by pinging it. */
+type elasticSearchProbe struct {
+	config *config.ElasticSearchConfiguration
+}
+
+/* Probe returns an error describing why elasticsearch is unreachable, or nil when it is reachable. */
+func (p *elasticSearchProbe) Probe() error {
+	elasticUrl := fmt.Sprintf(elasticUrlFormat, p.config.GetHost(), p.config.GetPort())
+
+	client, err := elastic.NewClient(elastic.SetURL(elasticUrl), elastic.SetSniff(false), elastic.SetHealthcheck(false))
+
+	if err != nil {
+		return err
+	}
+
+	defer client.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	_, _, err = client.Ping(elasticUrl).Do(ctx)
+
+	return err
+}
+
+/*
+streetViewApiProbe checks that the StreetView API is configured and that the last call made to it, if any, succeeded.
+Only Google's calls count, the other providers in the chain neither failing nor covering up for it.
+
+The API is not called here as every call, other than to the metadata endpoint, costs money.
+*/
+type streetViewApiProbe struct {
+	config *config.StreetViewApiConfiguration
+	status ApiClient.StreetViewApiStatus
+}
+
+/* Probe returns an error describing why the StreetView API is unusable, or nil when it is usable. */
+func (p *streetViewApiProbe) Probe() error {
+	if endpoint, err := url.Parse(p.config.GetEndpoint()); err != nil || endpoint.Host == "" {
+		return errors.New(fmt.Sprintf("streetview api endpoint: '%s' is not a valid url", p.config.GetEndpoint()))
+	}
+
+	if p.config.GetApiKey() == "" {
+		return errors.New("streetview api key is not configured")
+	}
+
+	lastCallAt, lastErr := p.status.GetLastOutcome(ApiClient.GoogleProvider)
+
+	if lastErr != nil && time.Since(lastCallAt) < failedCallTtl {
+		return errors.New(fmt.Sprintf("last call to the streetview api failed, error: '%s'", lastErr.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package Health
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Infrastructure/ApiClient"
+	"app/src/StreetViewImage/Infrastructure/Cache"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"time"
+)
+
+/* The sub-service names the health status is reported for, as requested in a grpc.health.v1.HealthCheckRequest. */
+const (
+	/* OverallService is the status of the server as a whole, which is the status of the StreetviewService. */
+	OverallService       = ""
+	StreetviewService    = "v1.StreetviewService"
+	RedisService         = "redis"
+	ElasticSearchService = "elasticsearch"
+	StreetViewApiService = "streetview_api"
+)
+
+/*
+HealthService implements the standard grpc.health.v1.Health service, reporting SERVING / NOT_SERVING per dependency.
+
+The StreetviewService (and the server as a whole) only depends on the StreetView API: without redis images are not
+cached and without elasticsearch logs are written to file, but images can still be served.
+*/
+type HealthService interface {
+	/* Register registers the health service with the server and starts probing the dependencies in the background. */
+	Register(server *grpc.Server)
+}
+
+/* healthService probes the dependencies at an interval and pushes status changes to the grpc health server. */
+type healthService struct {
+	server   *health.Server
+	probes   map[string]dependencyProbe
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+	interval time.Duration
+	logger   Logger.LoggingStrategy
+}
+
+/* NewHealthService returns a new HealthService. */
+func NewHealthService(
+	grpcConfig config.GrpcServerConfiguration,
+	redisClientFactory Cache.RedisClientFactory,
+	elasticConfig config.ElasticSearchConfiguration,
+	apiConfig config.StreetViewApiConfiguration,
+	apiStatus ApiClient.StreetViewApiStatus,
+	logger Logger.LoggingStrategy,
+) HealthService {
+	interval := grpcConfig.GetHealthCheckInterval()
+
+	if interval < 1 {
+		interval = 1
+	}
+
+	return &healthService{
+		server: health.NewServer(),
+		probes: map[string]dependencyProbe{
+			RedisService:         &redisProbe{clientFactory: &redisClientFactory},
+			ElasticSearchService: &elasticSearchProbe{config: &elasticConfig},
+			StreetViewApiService: &streetViewApiProbe{config: &apiConfig, status: apiStatus},
+		},
+		statuses: make(map[string]healthpb.HealthCheckResponse_ServingStatus),
+		interval: time.Duration(interval) * time.Second,
+		logger:   logger,
+	}
+}
+
+/*
+Register registers the health service with the server and starts probing the dependencies in the background.
+
+The dependencies are probed once before returning so the server never reports a status it hasn't checked. Watch streams
+are provided by the grpc health server, which pushes every status change set here to it's watchers.
+*/
+func (s *healthService) Register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, s.server)
+
+	s.probeDependencies()
+
+	go func() {
+		for range time.Tick(s.interval) {
+			s.probeDependencies()
+		}
+	}()
+}
+
+/* probeDependencies probes every dependency and updates the status of each sub-service. */
+func (s *healthService) probeDependencies() {
+	probeErrs := make(map[string]error, len(s.probes))
+
+	for service, probe := range s.probes {
+		probeErrs[service] = probe.Probe()
+
+		s.setStatus(service, probeErrs[service])
+	}
+
+	s.setStatus(StreetviewService, probeErrs[StreetViewApiService])
+
+	s.server.SetServingStatus(OverallService, s.statuses[StreetviewService])
+}
+
+/* setStatus sets the status of a sub-service from a probe's error, logging when the status changes. */
+func (s *healthService) setStatus(service string, probeErr error) {
+	status := healthpb.HealthCheckResponse_SERVING
+
+	if probeErr != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	if previousStatus, exists := s.statuses[service]; !exists || previousStatus != status {
+		if probeErr != nil {
+			s.logger.Warning(fmt.Sprintf("Health of '%s' is now: '%s', reason: '%s'", service, status, probeErr.Error()))
+		} else {
+			s.logger.Info(fmt.Sprintf("Health of '%s' is now: '%s'", service, status))
+		}
+	}
+
+	s.statuses[service] = status
+
+	s.server.SetServingStatus(service, status)
+}
@@ -2,8 +2,10 @@ package Server
 
 import (
 	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Application/QueryHandler"
 	"app/src/StreetViewImage/Infrastructure/ApiClient"
 	"app/src/StreetViewImage/Infrastructure/Logger"
+	"app/src/StreetViewImage/Presentation/Controller"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -30,7 +32,42 @@ var errorMap = []grpcError{
 	{Code: EmptyCorrelationIdCode, GrpcCode: codes.Unknown, Error: EmptyCorrelationIdErr},
 	{Code: InvalidCorrelationIdCode, GrpcCode: codes.InvalidArgument, Error: EmptyCorrelationIdErr},
 	/* User errors. */
+	{
+		Code: Controller.InvalidQueryParameterCode, GrpcCode: codes.InvalidArgument,
+		Error: Controller.InvalidQueryParameterErr,
+	},
 	{Code: ApiClient.InvalidLocationCode, GrpcCode: codes.NotFound, Error: ApiClient.InvalidLocationCodeErr},
+	{Code: ApiClient.InvalidPanoIdCode, GrpcCode: codes.NotFound, Error: ApiClient.InvalidPanoIdErr},
+	{Code: ApiClient.LocationNotFoundCode, GrpcCode: codes.NotFound, Error: ApiClient.LocationNotFoundErr},
+	{Code: ApiClient.InvalidRequestCode, GrpcCode: codes.InvalidArgument, Error: ApiClient.InvalidRequestErr},
+	{Code: QueryHandler.EmptyPanoIdCode, GrpcCode: codes.InvalidArgument, Error: QueryHandler.EmptyPanoIdErr},
+	{
+		Code: QueryHandler.InvalidPanoramaSlicesCode, GrpcCode: codes.InvalidArgument,
+		Error: QueryHandler.InvalidPanoramaSlicesErr,
+	},
+	{Code: QueryHandler.InvalidImageSizeCode, GrpcCode: codes.InvalidArgument, Error: QueryHandler.InvalidImageSizeErr},
+	{
+		Code: QueryHandler.InvalidImageScaleCode, GrpcCode: codes.InvalidArgument,
+		Error: QueryHandler.InvalidImageScaleErr,
+	},
+	{Code: QueryHandler.BatchTooLargeCode, GrpcCode: codes.InvalidArgument, Error: QueryHandler.BatchTooLargeErr},
+	{
+		Code: ApiClient.RateLimitExceededCode, GrpcCode: codes.ResourceExhausted,
+		Error: ApiClient.RateLimitExceededErr,
+	},
+	{Code: ApiClient.BudgetExceededCode, GrpcCode: codes.ResourceExhausted, Error: ApiClient.BudgetExceededErr},
+	{
+		Code: ApiClient.ApiKeysQuarantinedCode, GrpcCode: codes.ResourceExhausted,
+		Error: ApiClient.ApiKeysQuarantinedErr,
+	},
+	{Code: ApiClient.QuotaExceededCode, GrpcCode: codes.ResourceExhausted, Error: ApiClient.QuotaExceededErr},
+	/* A denied request is down to how the service is configured, not the user, so it is a server error. */
+	{Code: ApiClient.RequestDeniedCode, GrpcCode: codes.Internal, Error: ApiClient.RequestDeniedErr},
+	{Code: ApiClient.RequestCancelledCode, GrpcCode: codes.Canceled, Error: ApiClient.RequestCancelledErr},
+	{
+		Code: ApiClient.RequestDeadlineExceededCode, GrpcCode: codes.DeadlineExceeded,
+		Error: ApiClient.RequestDeadlineExceededErr,
+	},
 }
 
 /*
@@ -4,6 +4,7 @@ import (
 	"app/api/proto/v1"
 	"app/config"
 	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Infrastructure/Health"
 	"app/src/StreetViewImage/Infrastructure/Logger"
 	"app/src/StreetViewImage/Presentation/Controller"
 	"fmt"
@@ -32,6 +33,7 @@ type grpcServer struct {
 	logger       Logger.LoggingStrategy
 	retrier      MethodCallRetrier.Retrier
 	interceptors *RequestInterceptorGroup
+	health       Health.HealthService
 
 	/* injector allows the grpcServer to route to on-the-fly-injector-initialised controllers. */
 	injector Goij.Injector
@@ -43,11 +45,14 @@ func New(
 	retrierFactory RetrierFactory,
 	logger Logger.LoggingStrategy,
 	interceptors *RequestInterceptorGroup,
+	health Health.HealthService,
 	injector Goij.Injector,
 ) GrpcServer {
 	retrier := retrierFactory.Create(config)
 
-	return &grpcServer{config: config, logger: logger, retrier: retrier, interceptors: interceptors, injector: injector}
+	return &grpcServer{
+		config: config, logger: logger, retrier: retrier, interceptors: interceptors, health: health, injector: injector,
+	}
 }
 
 /* Run runs the GrpcServer. Great that we have to start docblocks with the method name isn't it? */
@@ -67,6 +72,7 @@ func (s *grpcServer) Run() {
 	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(middlewareInterceptors...)))
 
 	s.registerControllers(grpcServer)
+	s.health.Register(grpcServer)
 
 	_, rpcFatalErrors, wasSuccessful := s.retrier.ExecuteWithRetry(grpcServer, "Serve", listener)
 
@@ -75,13 +81,31 @@ func (s *grpcServer) Run() {
 	}
 }
 
-/* registerControllers registers the relevant controller endpoint with the server. */
+/*
+streetviewServiceServer groups the controllers for each rpc of the StreetviewService, as grpc requires a single server
+implementation for the whole service whereas we have one controller per rpc.
+*/
+type streetviewServiceServer struct {
+	*Controller.GetStreetViewImageController
+	*Controller.GetStreetViewImagesController
+	*Controller.GetStreetViewMetadataController
+	*Controller.GetStreetViewPanoImageController
+	*Controller.GetStreetViewPanoramaController
+	*Controller.GetStreetViewUsageController
+}
+
+/* registerControllers registers the relevant controller endpoints with the server. */
 func (s *grpcServer) registerControllers(server *grpc.Server) {
 	v1.RegisterStreetviewServiceServer(
 		server,
-		v1.StreetviewServiceServer(
+		v1.StreetviewServiceServer(&streetviewServiceServer{
 			s.injector.Make("GetStreetViewImageController").(*Controller.GetStreetViewImageController),
-		),
+			s.injector.Make("GetStreetViewImagesController").(*Controller.GetStreetViewImagesController),
+			s.injector.Make("GetStreetViewMetadataController").(*Controller.GetStreetViewMetadataController),
+			s.injector.Make("GetStreetViewPanoImageController").(*Controller.GetStreetViewPanoImageController),
+			s.injector.Make("GetStreetViewPanoramaController").(*Controller.GetStreetViewPanoramaController),
+			s.injector.Make("GetStreetViewUsageController").(*Controller.GetStreetViewUsageController),
+		}),
 	)
 }
 
@@ -0,0 +1,57 @@
+package Server
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+/*
+httpStatuses maps each grpc code to the closest http status code, so that the errorMap remains the single place where
+UserError codes are mapped. Codes not here are an internal server error.
+*/
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           http.StatusRequestTimeout,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+/* HttpErrorMapper is responsible for handling the conversion of a UserError to a http status code and message. */
+type HttpErrorMapper interface {
+	/* MapToHttpError maps a given UserError to the http status code and message to respond with. */
+	MapToHttpError(err error) (int, string)
+}
+
+/* httpErrorMapper converts errors to grpc errors first, then converts the grpc code to a http status code. */
+type httpErrorMapper struct {
+	grpcMapper GrpcErrorMapper
+}
+
+/* NewHttpErrorMapper returns a newly initialised HttpErrorMapper. */
+func NewHttpErrorMapper(grpcMapper GrpcErrorMapper) HttpErrorMapper {
+	return &httpErrorMapper{grpcMapper: grpcMapper}
+}
+
+/* MapToHttpError maps a given UserError to the http status code and message to respond with. */
+func (m *httpErrorMapper) MapToHttpError(err error) (int, string) {
+	grpcStatus := status.Convert(m.grpcMapper.MapToGrpcError(err))
+
+	httpStatus, isMapped := httpStatuses[grpcStatus.Code()]
+
+	if !isMapped {
+		httpStatus = http.StatusInternalServerError
+	}
+
+	return httpStatus, grpcStatus.Message()
+}
@@ -0,0 +1,97 @@
+package Server
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Infrastructure/Logger"
+	"app/src/StreetViewImage/Presentation/Controller"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/j7mbo/goij"
+	"net/http"
+	"time"
+)
+
+const (
+	/* correlationIdHeader is the optional request header containing the correlation id, generated when not provided. */
+	correlationIdHeader = "X-Correlation-Id"
+
+	/* Timeouts - the write timeout must allow for the StreetView API requests made on a cache miss. */
+	httpReadTimeout  = time.Duration(5 * time.Second)
+	httpWriteTimeout = time.Duration(60 * time.Second)
+)
+
+/* HttpServer represents a plain http server, started next to the GrpcServer, serving images to non-grpc clients. */
+type HttpServer interface {
+	/* Run runs the HttpServer. Blocks, so run it in a goroutine to run the GrpcServer next to it. */
+	Run()
+}
+
+/* httpServer encapsulates the initialisation, configuration and execution of a net/http.Server. */
+type httpServer struct {
+	config *config.WebServerConfiguration
+	logger Logger.LoggingStrategy
+
+	/* injector allows the httpServer to route to injector-initialised controllers. */
+	injector Goij.Injector
+}
+
+/* NewHttpServer creates a new HttpServer. */
+func NewHttpServer(
+	config *config.WebServerConfiguration, logger Logger.LoggingStrategy, injector Goij.Injector,
+) HttpServer {
+	return &httpServer{config: config, logger: logger, injector: injector}
+}
+
+/* Run runs the HttpServer. Blocks, so run it in a goroutine to run the GrpcServer next to it. */
+func (s *httpServer) Run() {
+	address := fmt.Sprintf(addressRegex, s.config.GetHost(), s.config.GetPort())
+
+	server := &http.Server{
+		Addr:         address,
+		Handler:      s.registerControllers(http.NewServeMux()),
+		ReadTimeout:  httpReadTimeout,
+		WriteTimeout: httpWriteTimeout,
+	}
+
+	s.logger.Info(
+		fmt.Sprintf("Will start listening on host: %s, port: %d for HTTP calls", s.config.GetHost(), s.config.GetPort()),
+	)
+
+	if err := server.ListenAndServe(); err != nil {
+		s.logger.Error(fmt.Sprintf("Fatal error for http server: %s", err.Error()))
+	}
+}
+
+/* registerControllers registers the relevant controller endpoints with the mux. */
+func (s *httpServer) registerControllers(mux *http.ServeMux) http.Handler {
+	mux.Handle(
+		"/v1/streetview",
+		s.injector.Make("GetStreetViewImageHttpController").(*Controller.GetStreetViewImageHttpController),
+	)
+
+	return s.addUuidToLogger(mux)
+}
+
+/*
+addUuidToLogger is a middleware function to retrieve the correlation id from the request header, or generate one when
+not provided, and update the logger with it; the http equivalent of RequestInterceptorGroup.addUuidToInjector.
+*/
+func (s *httpServer) addUuidToLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		correlationId, err := uuid.Parse(request.Header.Get(correlationIdHeader))
+
+		if err != nil {
+			correlationId = uuid.New()
+		}
+
+		s.logger.UpdateUuid(correlationId)
+
+		s.injector.Share(s.logger)
+
+		s.logger.Info(fmt.Sprintf("Request received: %s %s", request.Method, request.URL.String()))
+
+		writer.Header().Set(correlationIdHeader, correlationId.String())
+
+		next.ServeHTTP(writer, request)
+	})
+}
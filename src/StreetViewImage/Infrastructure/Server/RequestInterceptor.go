@@ -1,7 +1,6 @@
 package Server
 
 import (
-	"app/api/proto/v1"
 	"app/src/StreetViewImage/Application/Error"
 	"app/src/StreetViewImage/Infrastructure/Logger"
 	"context"
@@ -21,6 +20,11 @@ const (
 	InvalidCorrelationIdErr  = "invalid non-version-4 uuid provided, example v4 format: acca4678-fbbd-43b9-9d8a-83f8794935cb"
 )
 
+/* correlatedRequest is implemented by every request in our api, as they must all provide a correlation id. */
+type correlatedRequest interface {
+	GetCorrelationId() string
+}
+
 /* RequestInterceptorGroup returns user-defined middleware functions used for intercepting grpc requests. */
 type RequestInterceptorGroup struct {
 	Logger   Logger.LoggingStrategy
@@ -53,7 +57,14 @@ func (ri *RequestInterceptorGroup) addUuidToInjector(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
-	correlationId := req.(*v1.GetStreetViewRequest).CorrelationId
+	request, isCorrelated := req.(correlatedRequest)
+
+	/* Requests from outside our api, like health checks from the orchestrator, have no correlation id to share. */
+	if !isCorrelated {
+		return handler(ctx, req)
+	}
+
+	correlationId := request.GetCorrelationId()
 
 	if strings.Trim(correlationId, " ") == "" {
 		return nil, Error.UserError{Code: EmptyCorrelationIdCode, Err: EmptyCorrelationIdErr}
@@ -4,9 +4,17 @@ import (
 	"app/api/proto/v1"
 	"app/src/StreetViewImage/Application/Query"
 	"app/src/StreetViewImage/Application/QueryHandler"
+	"app/src/StreetViewImage/Domain"
 	"context"
+	"github.com/golang/protobuf/ptypes/wrappers"
 )
 
+/* imageSources maps the proto image source enum to the source values understood by the application. */
+var imageSources = map[v1.ImageSource]string{
+	v1.ImageSource_DEFAULT: Domain.SourceDefault,
+	v1.ImageSource_OUTDOOR: Domain.SourceOutdoor,
+}
+
 /* GetStreetViewImageController handles the request / response of a v1.GetStreetViewRequest. */
 type GetStreetViewImageController struct {
 	Handler    QueryHandler.GetStreetViewImageHandler
@@ -15,17 +23,50 @@ type GetStreetViewImageController struct {
 
 /* GetStreetViewImage handles the request / response of a v1.GetStreetViewRequest. */
 func (c *GetStreetViewImageController) GetStreetViewImage(
-	context context.Context, request *v1.GetStreetViewRequest,
+	ctx context.Context, request *v1.GetStreetViewRequest,
 ) (*v1.GetStreetViewResponse, error) {
-	query := Query.NewGetStreetViewImageQuery(float64(request.Latitude), float64(request.Longitude))
+	query := Query.NewGetStreetViewImageQuery(
+		float64(request.Latitude),
+		float64(request.Longitude),
+		floatValueOrNil(request.Heading),
+		floatValueOrNil(request.Pitch),
+		intValueOrNil(request.Fov),
+		intValueOrNil(request.Radius),
+		imageSources[request.Source],
+		intValueOrNil(request.Width),
+		intValueOrNil(request.Height),
+		intValueOrNil(request.Scale),
+	)
 
-	imageBytes, err := c.Handler.Handle(query)
+	image, err := c.Handler.Handle(ctx, query)
 
 	if err != nil {
 		return nil, c.GrpcMapper.MapToGrpcError(err)
 	}
 
-	response := &v1.GetStreetViewResponse{Image: imageBytes}
+	response := &v1.GetStreetViewResponse{Image: image.GetBytes(), Provider: image.GetProvider()}
 
 	return response, nil
 }
+
+/* floatValueOrNil converts an optional proto float to an optional float64, nil meaning it was not provided. */
+func floatValueOrNil(value *wrappers.FloatValue) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	floatValue := float64(value.Value)
+
+	return &floatValue
+}
+
+/* intValueOrNil converts an optional proto uint32 to an optional int, nil meaning it was not provided. */
+func intValueOrNil(value *wrappers.UInt32Value) *int {
+	if value == nil {
+		return nil
+	}
+
+	intValue := int(value.Value)
+
+	return &intValue
+}
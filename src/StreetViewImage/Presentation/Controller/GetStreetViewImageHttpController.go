@@ -0,0 +1,169 @@
+package Controller
+
+import (
+	"app/config"
+	"app/src/StreetViewImage/Application/Error"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Application/QueryHandler"
+	"app/src/StreetViewImage/Domain"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+/* Error constants. */
+const (
+	InvalidQueryParameterCode = "InvalidQueryParameter"
+	InvalidQueryParameterErr  = "invalid query parameters provided: lat and lon are required numbers, heading, pitch, " +
+		"fov, radius, width, height and scale are optional numbers and source is optionally one of: default, outdoor"
+)
+
+/* providerHeader is the response header reporting the imagery provider the image was retrieved from. */
+const providerHeader = "X-Imagery-Provider"
+
+/* httpImageSources maps the source query parameter to the source values understood by the application. */
+var httpImageSources = map[string]string{
+	"":                   Domain.SourceDefault,
+	Domain.SourceDefault: Domain.SourceDefault,
+	Domain.SourceOutdoor: Domain.SourceOutdoor,
+}
+
+/*
+GetStreetViewImageHttpController handles the request / response of a GET /v1/streetview?lat=..&lon=.. http request,
+the plain http equivalent of a v1.GetStreetViewRequest for clients (and CDNs) without a grpc stack.
+*/
+type GetStreetViewImageHttpController struct {
+	Handler    QueryHandler.GetStreetViewImageHandler
+	HttpMapper HttpErrorMapper
+	Config     *config.WebServerConfiguration
+}
+
+/*
+ServeHTTP handles the request / response of a GET /v1/streetview http request.
+
+The image is served with an ETag of it's contents and the time it was retrieved from the StreetView API as it's
+Last-Modified, so that http.ServeContent can respond to conditional requests with a 304 Not Modified.
+*/
+func (c *GetStreetViewImageHttpController) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		writer.Header().Set("Allow", "GET, HEAD")
+
+		c.writeError(writer, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+
+		return
+	}
+
+	query, err := c.createQuery(request.URL.Query())
+
+	if err != nil {
+		c.writeMappedError(writer, err)
+
+		return
+	}
+
+	image, err := c.Handler.Handle(request.Context(), query)
+
+	if err != nil {
+		c.writeMappedError(writer, err)
+
+		return
+	}
+
+	c.writeImage(writer, request, image)
+}
+
+/* writeImage writes the image with the caching headers, or a 304 Not Modified if the client's copy is still valid. */
+func (c *GetStreetViewImageHttpController) writeImage(
+	writer http.ResponseWriter, request *http.Request, image Domain.StreetViewImage,
+) {
+	checksum := sha1.Sum(image.GetBytes())
+
+	writer.Header().Set("Content-Type", "image/jpeg")
+	writer.Header().Set("ETag", fmt.Sprintf(`"%s"`, hex.EncodeToString(checksum[:])))
+	writer.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", c.Config.GetCacheMaxAge()))
+	writer.Header().Set(providerHeader, image.GetProvider())
+
+	http.ServeContent(writer, request, "", image.GetCreatedAt(), bytes.NewReader(image.GetBytes()))
+}
+
+/* writeMappedError maps the error to it's http status code and message and writes it as a json error response. */
+func (c *GetStreetViewImageHttpController) writeMappedError(writer http.ResponseWriter, err error) {
+	statusCode, message := c.HttpMapper.MapToHttpError(err)
+
+	c.writeError(writer, statusCode, message)
+}
+
+/* writeError writes the status code and message as a json error response. */
+func (c *GetStreetViewImageHttpController) writeError(writer http.ResponseWriter, statusCode int, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(writer).Encode(map[string]string{"error": message})
+}
+
+/* createQuery creates the query from the request's query parameters, or an error if any of them are invalid. */
+func (c *GetStreetViewImageHttpController) createQuery(values url.Values) (Query.GetStreetViewImage, error) {
+	invalidParameterErr := Error.UserError{Code: InvalidQueryParameterCode, Err: InvalidQueryParameterErr}
+
+	latitude, latErr := strconv.ParseFloat(values.Get("lat"), 64)
+	longitude, lonErr := strconv.ParseFloat(values.Get("lon"), 64)
+	heading, headingErr := optionalFloat(values, "heading")
+	pitch, pitchErr := optionalFloat(values, "pitch")
+	fov, fovErr := optionalInt(values, "fov")
+	radius, radiusErr := optionalInt(values, "radius")
+	width, widthErr := optionalInt(values, "width")
+	height, heightErr := optionalInt(values, "height")
+	scale, scaleErr := optionalInt(values, "scale")
+	source, isValidSource := httpImageSources[values.Get("source")]
+
+	for _, err := range []error{latErr, lonErr, headingErr, pitchErr, fovErr, radiusErr, widthErr, heightErr, scaleErr} {
+		if err != nil {
+			return nil, invalidParameterErr
+		}
+	}
+
+	if !isValidSource {
+		return nil, invalidParameterErr
+	}
+
+	return Query.NewGetStreetViewImageQuery(
+		latitude, longitude, heading, pitch, fov, radius, source, width, height, scale,
+	), nil
+}
+
+/* optionalFloat parses an optional query parameter as a float, nil meaning it was not provided. */
+func optionalFloat(values url.Values, key string) (*float64, error) {
+	if values.Get(key) == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(values.Get(key), 64)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+/* optionalInt parses an optional query parameter as a non-negative int, nil meaning it was not provided. */
+func optionalInt(values url.Values, key string) (*int, error) {
+	if values.Get(key) == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseUint(values.Get(key), 10, 31)
+
+	if err != nil {
+		return nil, err
+	}
+
+	intValue := int(value)
+
+	return &intValue, nil
+}
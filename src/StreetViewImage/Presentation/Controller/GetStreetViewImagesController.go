@@ -0,0 +1,66 @@
+package Controller
+
+import (
+	"app/api/proto/v1"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Application/QueryHandler"
+	"context"
+	"google.golang.org/grpc/status"
+)
+
+/* GetStreetViewImagesController handles the request / response of a v1.GetStreetViewImagesRequest. */
+type GetStreetViewImagesController struct {
+	Handler    QueryHandler.GetStreetViewImagesHandler
+	GrpcMapper GrpcErrorMapper
+}
+
+/*
+GetStreetViewImages handles the request / response of a v1.GetStreetViewImagesRequest.
+
+An error for a single location is mapped to a grpc code and message in it's own result so that the rest of the batch
+is still returned; only an error for the batch as a whole is returned as the grpc error.
+*/
+func (c *GetStreetViewImagesController) GetStreetViewImages(
+	ctx context.Context, request *v1.GetStreetViewImagesRequest,
+) (*v1.GetStreetViewImagesResponse, error) {
+	imageQueries := make([]Query.GetStreetViewImage, len(request.Locations))
+
+	for index, location := range request.Locations {
+		imageQueries[index] = Query.NewGetStreetViewImageQuery(
+			float64(location.Latitude),
+			float64(location.Longitude),
+			floatValueOrNil(location.Heading),
+			floatValueOrNil(location.Pitch),
+			intValueOrNil(location.Fov),
+			intValueOrNil(location.Radius),
+			imageSources[location.Source],
+			intValueOrNil(location.Width),
+			intValueOrNil(location.Height),
+			intValueOrNil(location.Scale),
+		)
+	}
+
+	results, err := c.Handler.Handle(ctx, Query.NewGetStreetViewImagesQuery(imageQueries))
+
+	if err != nil {
+		return nil, c.GrpcMapper.MapToGrpcError(err)
+	}
+
+	response := &v1.GetStreetViewImagesResponse{Results: make([]*v1.StreetViewImageResult, len(results))}
+
+	for index, result := range results {
+		if result.Err != nil {
+			grpcStatus := status.Convert(c.GrpcMapper.MapToGrpcError(result.Err))
+
+			response.Results[index] = &v1.StreetViewImageResult{
+				Code: uint32(grpcStatus.Code()), Error: grpcStatus.Message(),
+			}
+
+			continue
+		}
+
+		response.Results[index] = &v1.StreetViewImageResult{Image: result.Image, Provider: result.Provider}
+	}
+
+	return response, nil
+}
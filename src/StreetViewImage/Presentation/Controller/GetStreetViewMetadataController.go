@@ -0,0 +1,44 @@
+package Controller
+
+import (
+	"app/api/proto/v1"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Application/QueryHandler"
+	"context"
+)
+
+/* GetStreetViewMetadataController handles the request / response of a v1.GetStreetViewMetadataRequest. */
+type GetStreetViewMetadataController struct {
+	Handler    QueryHandler.GetStreetViewMetadataHandler
+	GrpcMapper GrpcErrorMapper
+}
+
+/* GetStreetViewMetadata handles the request / response of a v1.GetStreetViewMetadataRequest. */
+func (c *GetStreetViewMetadataController) GetStreetViewMetadata(
+	ctx context.Context, request *v1.GetStreetViewMetadataRequest,
+) (*v1.GetStreetViewMetadataResponse, error) {
+	query := Query.NewGetStreetViewMetadataQuery(
+		float64(request.Latitude),
+		float64(request.Longitude),
+		intValueOrNil(request.Radius),
+		imageSources[request.Source],
+	)
+
+	metadata, err := c.Handler.Handle(ctx, query)
+
+	if err != nil {
+		return nil, c.GrpcMapper.MapToGrpcError(err)
+	}
+
+	response := &v1.GetStreetViewMetadataResponse{
+		Status:    string(metadata.Status),
+		PanoId:    metadata.PanoId,
+		Date:      metadata.Date,
+		Copyright: metadata.Copyright,
+		Latitude:  metadata.Location.Latitude,
+		Longitude: metadata.Location.Longitude,
+		Provider:  metadata.Provider,
+	}
+
+	return response, nil
+}
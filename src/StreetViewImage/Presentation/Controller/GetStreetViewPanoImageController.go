@@ -0,0 +1,39 @@
+package Controller
+
+import (
+	"app/api/proto/v1"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Application/QueryHandler"
+	"context"
+)
+
+/* GetStreetViewPanoImageController handles the request / response of a v1.GetStreetViewPanoRequest. */
+type GetStreetViewPanoImageController struct {
+	Handler    QueryHandler.GetStreetViewPanoImageHandler
+	GrpcMapper GrpcErrorMapper
+}
+
+/* GetStreetViewPanoImage handles the request / response of a v1.GetStreetViewPanoRequest. */
+func (c *GetStreetViewPanoImageController) GetStreetViewPanoImage(
+	ctx context.Context, request *v1.GetStreetViewPanoRequest,
+) (*v1.GetStreetViewResponse, error) {
+	query := Query.NewGetStreetViewPanoImageQuery(
+		request.PanoId,
+		floatValueOrNil(request.Heading),
+		floatValueOrNil(request.Pitch),
+		intValueOrNil(request.Fov),
+		intValueOrNil(request.Width),
+		intValueOrNil(request.Height),
+		intValueOrNil(request.Scale),
+	)
+
+	image, err := c.Handler.Handle(ctx, query)
+
+	if err != nil {
+		return nil, c.GrpcMapper.MapToGrpcError(err)
+	}
+
+	response := &v1.GetStreetViewResponse{Image: image.GetBytes(), Provider: image.GetProvider()}
+
+	return response, nil
+}
@@ -0,0 +1,40 @@
+package Controller
+
+import (
+	"app/api/proto/v1"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Application/QueryHandler"
+	"context"
+)
+
+/* GetStreetViewPanoramaController handles the request / response of a v1.GetStreetViewPanoramaRequest. */
+type GetStreetViewPanoramaController struct {
+	Handler    QueryHandler.GetStreetViewPanoramaHandler
+	GrpcMapper GrpcErrorMapper
+}
+
+/* GetStreetViewPanorama handles the request / response of a v1.GetStreetViewPanoramaRequest. */
+func (c *GetStreetViewPanoramaController) GetStreetViewPanorama(
+	ctx context.Context, request *v1.GetStreetViewPanoramaRequest,
+) (*v1.GetStreetViewResponse, error) {
+	query := Query.NewGetStreetViewPanoramaQuery(
+		float64(request.Latitude),
+		float64(request.Longitude),
+		int(request.Slices),
+		intValueOrNil(request.Width),
+		intValueOrNil(request.Height),
+		floatValueOrNil(request.Pitch),
+		intValueOrNil(request.Radius),
+		imageSources[request.Source],
+	)
+
+	image, err := c.Handler.Handle(ctx, query)
+
+	if err != nil {
+		return nil, c.GrpcMapper.MapToGrpcError(err)
+	}
+
+	response := &v1.GetStreetViewResponse{Image: image.GetBytes(), Provider: image.GetProvider()}
+
+	return response, nil
+}
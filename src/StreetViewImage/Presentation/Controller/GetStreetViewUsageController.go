@@ -0,0 +1,50 @@
+package Controller
+
+import (
+	"app/api/proto/v1"
+	"app/src/StreetViewImage/Application/Query"
+	"app/src/StreetViewImage/Application/QueryHandler"
+	"context"
+	"time"
+)
+
+/* GetStreetViewUsageController handles the request / response of a v1.GetStreetViewUsageRequest. */
+type GetStreetViewUsageController struct {
+	Handler    QueryHandler.GetStreetViewUsageHandler
+	GrpcMapper GrpcErrorMapper
+}
+
+/* GetStreetViewUsage handles the request / response of a v1.GetStreetViewUsageRequest. */
+func (c *GetStreetViewUsageController) GetStreetViewUsage(
+	ctx context.Context, request *v1.GetStreetViewUsageRequest,
+) (*v1.GetStreetViewUsageResponse, error) {
+	usage, err := c.Handler.Handle(ctx, Query.NewGetStreetViewUsageQuery())
+
+	if err != nil {
+		return nil, c.GrpcMapper.MapToGrpcError(err)
+	}
+
+	response := &v1.GetStreetViewUsageResponse{
+		Month:            usage.Month,
+		BillableRequests: uint64(usage.BillableRequests),
+		Budget:           uint64(usage.Budget),
+	}
+
+	for _, keyUsage := range usage.ApiKeys {
+		apiKey := &v1.StreetViewApiKeyUsage{
+			Id:               keyUsage.Id,
+			Weight:           uint32(keyUsage.Weight),
+			Requests:         uint64(keyUsage.Requests),
+			BillableRequests: uint64(keyUsage.BillableRequests),
+			Refusals:         uint64(keyUsage.Refusals),
+		}
+
+		if !keyUsage.QuarantinedUntil.IsZero() {
+			apiKey.QuarantinedUntil = keyUsage.QuarantinedUntil.UTC().Format(time.RFC3339)
+		}
+
+		response.ApiKeys = append(response.ApiKeys, apiKey)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,10 @@
+package Controller
+
+/*
+HttpErrorMapper is a controller-specific interface that Server.HttpErrorMapper implements, for the same reason as the
+GrpcErrorMapper: to avoid cyclic dependencies. app.go delegates the initialisation of this interface too.
+*/
+type HttpErrorMapper interface {
+	/* MapToHttpError maps a given UserError to the http status code and message to respond with. */
+	MapToHttpError(err error) (int, string)
+}
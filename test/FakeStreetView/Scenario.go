@@ -0,0 +1,65 @@
+package FakeStreetView
+
+/* The endpoints of the api a scenario can be limited to. */
+const (
+	EndpointImage    = "image"
+	EndpointMetadata = "metadata"
+)
+
+/* The metadata statuses, see: https://developers.google.com/maps/documentation/streetview/metadata#status-codes. */
+const (
+	StatusOk             = "OK"
+	StatusZeroResults    = "ZERO_RESULTS"
+	StatusNotFound       = "NOT_FOUND"
+	StatusOverQueryLimit = "OVER_QUERY_LIMIT"
+	StatusRequestDenied  = "REQUEST_DENIED"
+	StatusInvalidRequest = "INVALID_REQUEST"
+	StatusUnknownError   = "UNKNOWN_ERROR"
+)
+
+/*
+Scenario scripts how the fake responds to the requests it matches, instead of with an image or it's metadata. Scenarios
+are matched in the order they were added, and a scenario that is used up no longer matches anything.
+
+For example, a burst of three server errors for every location followed by normal responses:
+
+	server.Script(FakeStreetView.Scenario{StatusCode: 503, Times: 3})
+
+Scenarios are JSON encodable so that the standalone server can be scripted too, see: cmd/main.go.
+*/
+type Scenario struct {
+	/* Location limits the scenario to requests for the location, as requested ("55.000000,-42.000000"), or pano id. */
+	Location string `json:"location"`
+	/* Endpoint limits the scenario to requests to either the EndpointImage or EndpointMetadata, empty for both. */
+	Endpoint string `json:"endpoint"`
+	/* Times is the number of requests the scenario is used for before it is used up, 0 for every request. */
+	Times int `json:"times"`
+
+	/*
+		Status is the metadata status responded with. The image endpoint responds with the http status google does for
+		the same reason instead, such as a 403 for REQUEST_DENIED.
+	*/
+	Status string `json:"status"`
+	/* StatusCode is the http status code responded with, along with an empty body, taking precedence over Status. */
+	StatusCode int `json:"statusCode"`
+	/* RetryAfter is the seconds responded with in a Retry-After header, along with a StatusCode such as 429. */
+	RetryAfter int `json:"retryAfter"`
+	/* DelayMillis is the milliseconds waited before responding, or before the request is abandoned by the client. */
+	DelayMillis int `json:"delayMillis"`
+	/* Malformed responds with the first half of the body only: invalid JSON or a truncated image. */
+	Malformed bool `json:"malformed"`
+	/*
+		DropMillis responds with the first half of the body whilst declaring the length of all of it, then drops the
+		connection after the milliseconds, as when the network fails mid-response.
+	*/
+	DropMillis int `json:"dropMillis"`
+}
+
+/* matches returns whether the scenario is to be used for a request for the location to the endpoint. */
+func (s *Scenario) matches(endpoint string, location string) bool {
+	if s.Location != "" && s.Location != location {
+		return false
+	}
+
+	return s.Endpoint == "" || s.Endpoint == endpoint
+}
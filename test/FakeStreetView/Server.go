@@ -0,0 +1,444 @@
+package FakeStreetView
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	/* Path is the path of the image endpoint, as in google's endpoint, the metadata endpoint being under it. */
+	Path         = "/maps/api/streetview"
+	metadataPath = Path + "/metadata"
+
+	/* ScenariosPath is the path the standalone server is scripted at, see: ServeScenarios. */
+	ScenariosPath = "/fake/scenarios"
+
+	/* maxSize is the largest width and height an image can be requested with, as with google. */
+	maxSize = 640
+
+	/* Copyright is the copyright of every panorama described by the fake. */
+	Copyright = "© Fake Street View"
+	/* Date is the date every panorama described by the fake was captured. */
+	Date = "2019-05"
+)
+
+/*
+Server is a fake of Google's Street View Static API, responding to the image and metadata endpoints much as google does,
+so that the api client can be tested and the application run locally without calling (and paying) google.
+
+Every location has an image unless scripted otherwise (see: Scenario), a plain coloured JPEG of the requested size that
+differs between locations, and it's metadata snaps nowhere: the panorama is exactly where requested.
+
+Requests are refused as google does unless they have one of the api keys, when any are given, and a valid signature
+when a signing secret is given. It is safe to use concurrently, and is a http.Handler to serve however is convenient.
+*/
+type Server struct {
+	mutex sync.Mutex
+
+	/* apiKeys are the keys requests are accepted with, any key being accepted when there are none. */
+	apiKeys map[string]bool
+	/* signingSecret is the url-safe base64 secret requests must be signed with, not checked when empty. */
+	signingSecret string
+
+	scenarios []*Scenario
+
+	/* requests counts the requests received by each endpoint, including those refused. */
+	requests map[string]int
+}
+
+/* NewServer returns a new Server accepting the api keys, any key when there are none, and the signing secret. */
+func NewServer(apiKeys []string, signingSecret string) *Server {
+	server := &Server{
+		apiKeys:       make(map[string]bool),
+		signingSecret: signingSecret,
+		requests:      make(map[string]int),
+	}
+
+	for _, key := range apiKeys {
+		if key != "" {
+			server.apiKeys[key] = true
+		}
+	}
+
+	return server
+}
+
+/*
+NewTestServer starts a new Server with httptest, for tests, returning the server to script along with the endpoint to
+configure the api client with (STREETVIEW_API_ENDPOINT). The httptest server must be closed once done with.
+*/
+func NewTestServer(apiKeys []string, signingSecret string) (*Server, *httptest.Server, string) {
+	server := NewServer(apiKeys, signingSecret)
+	testServer := httptest.NewServer(server)
+
+	return server, testServer, testServer.URL + Path
+}
+
+/* Script adds the scenarios to be matched after the ones added before them. */
+func (s *Server) Script(scenarios ...Scenario) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for index := range scenarios {
+		scenario := scenarios[index]
+		s.scenarios = append(s.scenarios, &scenario)
+	}
+}
+
+/* Reset forgets every scenario and the requests counted so far. */
+func (s *Server) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.scenarios = nil
+	s.requests = make(map[string]int)
+}
+
+/* GetRequestCount retrieves the number of requests the endpoint has received, including those refused. */
+func (s *Server) GetRequestCount(endpoint string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.requests[endpoint]
+}
+
+/* ServeHTTP responds to a request to the image or metadata endpoint, or to script the server at the ScenariosPath. */
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var endpoint string
+
+	switch strings.TrimRight(r.URL.Path, "/") {
+	case Path:
+		endpoint = EndpointImage
+	case metadataPath:
+		endpoint = EndpointMetadata
+	case ScenariosPath:
+		s.ServeScenarios(w, r)
+
+		return
+	default:
+		http.NotFound(w, r)
+
+		return
+	}
+
+	query := r.URL.Query()
+
+	location := query.Get("location")
+
+	if location == "" {
+		location = query.Get("pano")
+	}
+
+	s.countRequest(endpoint)
+
+	if err := s.authenticate(r); err != nil {
+		s.respondWithStatus(w, endpoint, StatusRequestDenied, err.Error())
+
+		return
+	}
+
+	if location == "" {
+		s.respondWithStatus(w, endpoint, StatusInvalidRequest, "Either a location or a pano id is required.")
+
+		return
+	}
+
+	scenario := s.useScenario(endpoint, location)
+
+	if scenario != nil && !s.play(w, r, endpoint, scenario) {
+		return
+	}
+
+	body := s.respondWithImage
+
+	if endpoint == EndpointMetadata {
+		body = s.respondWithMetadata
+	}
+
+	if scenario != nil && scenario.DropMillis > 0 {
+		s.drop(w, r, body(w, query, location), scenario)
+
+		return
+	}
+
+	_, _ = w.Write(truncate(body(w, query, location), scenario != nil && scenario.Malformed))
+}
+
+/*
+drop responds with the first half of the body whilst declaring the length of all of it, then returns once the
+scenario's milliseconds have passed, which closes the connection as the declared length was not written.
+*/
+func (s *Server) drop(w http.ResponseWriter, r *http.Request, body []byte, scenario *Scenario) {
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	_, _ = w.Write(truncate(body, true))
+
+	if flusher, isFlusher := w.(http.Flusher); isFlusher {
+		flusher.Flush()
+	}
+
+	select {
+	case <-time.After(time.Duration(scenario.DropMillis) * time.Millisecond):
+	case <-r.Context().Done():
+	}
+}
+
+/*
+ServeScenarios scripts the server over http: a POST of a JSON array of scenarios adds them (see: Script), whereas a
+DELETE resets the server (see: Reset).
+*/
+func (s *Server) ServeScenarios(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var scenarios []Scenario
+
+		if err := json.NewDecoder(r.Body).Decode(&scenarios); err != nil {
+			http.Error(w, fmt.Sprintf("invalid scenarios, error: '%s'", err.Error()), http.StatusBadRequest)
+
+			return
+		}
+
+		s.Script(scenarios...)
+	case http.MethodDelete:
+		s.Reset()
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/* countRequest counts a request received by the endpoint. */
+func (s *Server) countRequest(endpoint string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests[endpoint]++
+}
+
+/*
+useScenario returns the first scenario a request for the location to the endpoint matches, if any, using it up. Only
+requests that are not refused are matched, so that a scenario is never used up by a request it has no effect on.
+*/
+func (s *Server) useScenario(endpoint string, location string) *Scenario {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for index, scenario := range s.scenarios {
+		if !scenario.matches(endpoint, location) {
+			continue
+		}
+
+		if scenario.Times > 0 {
+			scenario.Times--
+
+			if scenario.Times == 0 {
+				s.scenarios = append(s.scenarios[:index], s.scenarios[index+1:]...)
+			}
+		}
+
+		return scenario
+	}
+
+	return nil
+}
+
+/*
+play plays the scenario up to the response, returning whether the response is still to be written as normal, as is
+the case when the scenario only delays or malforms it.
+*/
+func (s *Server) play(w http.ResponseWriter, r *http.Request, endpoint string, scenario *Scenario) bool {
+	if scenario.DelayMillis > 0 {
+		select {
+		case <-time.After(time.Duration(scenario.DelayMillis) * time.Millisecond):
+		case <-r.Context().Done():
+			return false
+		}
+	}
+
+	if scenario.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(scenario.RetryAfter))
+	}
+
+	if scenario.StatusCode != 0 {
+		w.WriteHeader(scenario.StatusCode)
+
+		return false
+	}
+
+	if scenario.Status != "" && scenario.Status != StatusOk {
+		s.respondWithStatus(w, endpoint, scenario.Status, "Scripted by a scenario.")
+
+		return false
+	}
+
+	return true
+}
+
+/*
+authenticate checks the request has one of the api keys and, when a signing secret is given, is signed with it: a
+HMAC-SHA1 of the path and query up to the signature, keyed with the url-safe base64 decoded secret.
+*/
+func (s *Server) authenticate(r *http.Request) error {
+	key := r.URL.Query().Get("key")
+
+	if key == "" {
+		return errors.New("You must use an API key to authenticate each request to Google Maps Platform APIs.")
+	}
+
+	if len(s.apiKeys) > 0 && !s.apiKeys[key] {
+		return errors.New("The provided API key is invalid.")
+	}
+
+	if s.signingSecret == "" {
+		return nil
+	}
+
+	signatureIndex := strings.LastIndex(r.URL.RawQuery, "&signature=")
+
+	if signatureIndex < 0 {
+		return errors.New("This request must be signed.")
+	}
+
+	secret, err := base64.URLEncoding.DecodeString(s.signingSecret)
+
+	if err != nil {
+		return errors.New("The fake's signing secret is not url-safe base64.")
+	}
+
+	mac := hmac.New(sha1.New, secret)
+	_, _ = mac.Write([]byte(r.URL.EscapedPath() + "?" + r.URL.RawQuery[:signatureIndex]))
+
+	signature := r.URL.RawQuery[signatureIndex+len("&signature="):]
+
+	if !hmac.Equal([]byte(signature), []byte(base64.URLEncoding.EncodeToString(mac.Sum(nil)))) {
+		return errors.New("The signature of this request is invalid.")
+	}
+
+	return nil
+}
+
+/*
+respondWithStatus responds with a metadata status other than OK. The metadata endpoint responds with it in the body as
+google does, whereas the image endpoint responds with the http status google does for the same reason.
+*/
+func (s *Server) respondWithStatus(w http.ResponseWriter, endpoint string, status string, message string) {
+	if endpoint == EndpointMetadata {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": status, "error_message": message})
+
+		return
+	}
+
+	statusCode := http.StatusNotFound
+
+	switch status {
+	case StatusOverQueryLimit, StatusRequestDenied:
+		statusCode = http.StatusForbidden
+	case StatusInvalidRequest:
+		statusCode = http.StatusBadRequest
+	case StatusUnknownError:
+		statusCode = http.StatusInternalServerError
+	}
+
+	http.Error(w, message, statusCode)
+}
+
+/* respondWithMetadata returns the metadata of the panorama at the location (or with the pano id) as google would. */
+func (s *Server) respondWithMetadata(w http.ResponseWriter, query url.Values, location string) []byte {
+	metadata := map[string]interface{}{
+		"status":    StatusOk,
+		"pano_id":   fmt.Sprintf("FAKE%x", sha1.Sum([]byte(location)))[:26],
+		"date":      Date,
+		"copyright": Copyright,
+	}
+
+	if coordinates := strings.Split(location, ","); len(coordinates) == 2 {
+		latitude, _ := strconv.ParseFloat(strings.TrimSpace(coordinates[0]), 64)
+		longitude, _ := strconv.ParseFloat(strings.TrimSpace(coordinates[1]), 64)
+
+		metadata["location"] = map[string]float64{"lat": latitude, "lng": longitude}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	body, _ := json.Marshal(metadata)
+
+	return body
+}
+
+/* respondWithImage returns a JPEG of the requested size, coloured by the location so that each one's image differs. */
+func (s *Server) respondWithImage(w http.ResponseWriter, query url.Values, location string) []byte {
+	width, height := parseSize(query.Get("size"))
+
+	hash := sha1.Sum([]byte(location))
+	fill := color.RGBA{R: hash[0], G: hash[1], B: hash[2], A: 255}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, fill)
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	_ = jpeg.Encode(&buffer, img, nil)
+
+	w.Header().Set("Content-Type", "image/jpeg")
+
+	return buffer.Bytes()
+}
+
+/* parseSize parses the size parameter, as WxH, clamped to the max size and defaulting to it when invalid. */
+func parseSize(size string) (int, int) {
+	dimensions := strings.Split(size, "x")
+
+	if len(dimensions) != 2 {
+		return maxSize, maxSize
+	}
+
+	width, widthErr := strconv.Atoi(dimensions[0])
+	height, heightErr := strconv.Atoi(dimensions[1])
+
+	if widthErr != nil || heightErr != nil || width < 1 || height < 1 {
+		return maxSize, maxSize
+	}
+
+	if width > maxSize {
+		width = maxSize
+	}
+
+	if height > maxSize {
+		height = maxSize
+	}
+
+	return width, height
+}
+
+/* truncate returns the first half of the body when it is to be malformed, otherwise the body as is. */
+func truncate(body []byte, malformed bool) []byte {
+	if !malformed {
+		return body
+	}
+
+	return body[:len(body)/2]
+}
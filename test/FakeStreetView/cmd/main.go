@@ -0,0 +1,55 @@
+package main
+
+import (
+	"app/test/FakeStreetView"
+	"encoding/json"
+	"fmt"
+	"github.com/j7mbo/goenvconfig"
+	"log"
+	"net/http"
+	"strings"
+)
+
+/*
+configuration contains the configuration of the standalone fake Street View server, which the application is pointed at
+with: STREETVIEW_API_ENDPOINT=http://<host>:<port>/maps/api/streetview.
+*/
+type configuration struct {
+	port int `env:"FAKE_STREETVIEW_PORT" default:"8090"`
+	/* Comma separated api keys requests are accepted with, any key when empty. */
+	apiKeys string `env:"FAKE_STREETVIEW_API_KEYS"`
+	/* The url-safe base64 secret requests must be signed with, not checked when empty. */
+	signingSecret string `env:"FAKE_STREETVIEW_SIGNING_SECRET"`
+	/* A JSON array of scenarios to script the server with from the start, see: FakeStreetView.Scenario. */
+	scenarios string `env:"FAKE_STREETVIEW_SCENARIOS"`
+}
+
+/*
+main runs the fake Street View server standalone, for running the application locally or in docker-compose without
+calling google. It can be scripted further whilst running with a POST to /fake/scenarios, for example:
+
+	curl -X POST localhost:8090/fake/scenarios -d '[{"location": "55.000000,-42.000000", "status": "ZERO_RESULTS"}]'
+*/
+func main() {
+	config := &configuration{}
+
+	if err := goenvconfig.NewGoEnvParser().Parse(config); err != nil {
+		log.Fatalf("Unable to parse the configuration, error: '%s'", err.Error())
+	}
+
+	server := FakeStreetView.NewServer(strings.Split(config.apiKeys, ","), config.signingSecret)
+
+	if config.scenarios != "" {
+		var scenarios []FakeStreetView.Scenario
+
+		if err := json.Unmarshal([]byte(config.scenarios), &scenarios); err != nil {
+			log.Fatalf("Invalid FAKE_STREETVIEW_SCENARIOS, error: '%s'", err.Error())
+		}
+
+		server.Script(scenarios...)
+	}
+
+	log.Printf("Fake Street View server listening on port: '%d'", config.port)
+
+	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", config.port), server))
+}